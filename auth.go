@@ -0,0 +1,69 @@
+package k8sutils
+
+import (
+	"context"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReviewToken validates a bearer token with the TokenReview API and returns the authenticated user.
+// ErrUnauthenticated is returned when the API server rejects the token.
+func (kc *Clientset) ReviewToken(ctx context.Context, token string, audiences ...string) (*authenticationv1.UserInfo, error) {
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: audiences,
+		},
+	}
+	result, err := kc.clientset.AuthenticationV1().TokenReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error creating token review: %w", err)
+	}
+	if !result.Status.Authenticated {
+		if result.Status.Error != "" {
+			return nil, fmt.Errorf("%w: %s", ErrUnauthenticated, result.Status.Error)
+		}
+		return nil, ErrUnauthenticated
+	}
+	return &result.Status.User, nil
+}
+
+// AccessDecision is the outcome of a SubjectAccessReview.
+type AccessDecision struct {
+	Allowed bool
+	Denied  bool
+	Reason  string
+}
+
+// ReviewAccess asks the API server whether user may perform the action described by attrs,
+// using the SubjectAccessReview API.
+func (kc *Clientset) ReviewAccess(ctx context.Context, user *authenticationv1.UserInfo, attrs authorizationv1.ResourceAttributes) (*AccessDecision, error) {
+	if user == nil {
+		return nil, fmt.Errorf("error creating subject access review: user is required")
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attrs,
+			User:               user.Username,
+			Groups:             user.Groups,
+			UID:                user.UID,
+			Extra:              make(map[string]authorizationv1.ExtraValue, len(user.Extra)),
+		},
+	}
+	for k, v := range user.Extra {
+		review.Spec.Extra[k] = authorizationv1.ExtraValue(v)
+	}
+
+	result, err := kc.clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error creating subject access review: %w", err)
+	}
+	return &AccessDecision{
+		Allowed: result.Status.Allowed,
+		Denied:  result.Status.Denied,
+		Reason:  result.Status.Reason,
+	}, nil
+}
@@ -0,0 +1,151 @@
+package k8sutils
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NodeCapacity struct {
+	Name        string
+	Allocatable corev1.ResourceList
+	Requested   corev1.ResourceList
+}
+
+// Available returns allocatable minus requested for the resource, never below zero.
+func (n NodeCapacity) Available(name corev1.ResourceName) resource.Quantity {
+	return available(n.Allocatable, n.Requested, name)
+}
+
+type CapacitySnapshot struct {
+	Nodes       []NodeCapacity
+	Allocatable corev1.ResourceList
+	Requested   corev1.ResourceList
+}
+
+func (c CapacitySnapshot) Available(name corev1.ResourceName) resource.Quantity {
+	return available(c.Allocatable, c.Requested, name)
+}
+
+// ClusterCapacity summarizes allocatable vs requested CPU and memory per node and cluster-wide.
+// Requests of pods in a terminal phase are not counted.
+func (kc *Clientset) ClusterCapacity(ctx context.Context) (*CapacitySnapshot, error) {
+	nodes, err := kc.ListNode(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing nodes: %w", err)
+	}
+	pods, err := kc.clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: "status.phase!=Succeeded,status.phase!=Failed",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods: %w", err)
+	}
+
+	requestedByNode := make(map[string]corev1.ResourceList, len(nodes.Items))
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		rl, ok := requestedByNode[pod.Spec.NodeName]
+		if !ok {
+			rl = newCapacityList()
+			requestedByNode[pod.Spec.NodeName] = rl
+		}
+		addResourceList(rl, podRequests(pod))
+	}
+
+	snapshot := &CapacitySnapshot{
+		Nodes:       make([]NodeCapacity, 0, len(nodes.Items)),
+		Allocatable: newCapacityList(),
+		Requested:   newCapacityList(),
+	}
+	for _, node := range nodes.Items {
+		nc := NodeCapacity{
+			Name:        node.Name,
+			Allocatable: newCapacityList(),
+			Requested:   newCapacityList(),
+		}
+		addResourceList(nc.Allocatable, node.Status.Allocatable)
+		if rl, ok := requestedByNode[node.Name]; ok {
+			addResourceList(nc.Requested, rl)
+		}
+		addResourceList(snapshot.Allocatable, nc.Allocatable)
+		addResourceList(snapshot.Requested, nc.Requested)
+		snapshot.Nodes = append(snapshot.Nodes, nc)
+	}
+	return snapshot, nil
+}
+
+var capacityResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+func newCapacityList() corev1.ResourceList {
+	rl := corev1.ResourceList{}
+	for _, name := range capacityResources {
+		rl[name] = resource.Quantity{}
+	}
+	return rl
+}
+
+// addResourceList adds the tracked resources of src into dst.
+func addResourceList(dst, src corev1.ResourceList) {
+	for _, name := range capacityResources {
+		q, ok := src[name]
+		if !ok {
+			continue
+		}
+		sum := dst[name]
+		sum.Add(q)
+		dst[name] = sum
+	}
+}
+
+// podRequests follows the scheduler's rule: restartable (sidecar) init containers keep running alongside the app
+// containers, so they are added to the app sum; every other init container runs alone, next to the sidecars started
+// before it. The request is the larger of the two, plus pod overhead.
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	reqs := newCapacityList()
+	for _, c := range pod.Spec.Containers {
+		addResourceList(reqs, c.Resources.Requests)
+	}
+
+	sidecars := newCapacityList()
+	initReqs := newCapacityList()
+	for _, c := range pod.Spec.InitContainers {
+		peak := sidecars
+		if c.RestartPolicy != nil && *c.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			addResourceList(sidecars, c.Resources.Requests)
+		} else {
+			peak = newCapacityList()
+			addResourceList(peak, sidecars)
+			addResourceList(peak, c.Resources.Requests)
+		}
+		maxResourceList(initReqs, peak)
+	}
+
+	addResourceList(reqs, sidecars)
+	maxResourceList(reqs, initReqs)
+	addResourceList(reqs, pod.Spec.Overhead)
+	return reqs
+}
+
+// maxResourceList raises the tracked resources of dst to at least those of src.
+func maxResourceList(dst, src corev1.ResourceList) {
+	for _, name := range capacityResources {
+		if q, ok := src[name]; ok && q.Cmp(dst[name]) > 0 {
+			dst[name] = q.DeepCopy()
+		}
+	}
+}
+
+func available(allocatable, requested corev1.ResourceList, name corev1.ResourceName) resource.Quantity {
+	q := allocatable[name].DeepCopy()
+	q.Sub(requested[name])
+	if q.Sign() < 0 {
+		return resource.Quantity{}
+	}
+	return q
+}
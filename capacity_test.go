@@ -0,0 +1,84 @@
+package k8sutils
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestPodRequests(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	container := func(cpu, memory string) corev1.Container {
+		return corev1.Container{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}}}
+	}
+	sidecar := func(cpu, memory string) corev1.Container {
+		c := container(cpu, memory)
+		c.RestartPolicy = &always
+		return c
+	}
+
+	tests := []struct {
+		name       string
+		spec       corev1.PodSpec
+		wantCPU    string
+		wantMemory string
+	}{
+		{
+			name:       "app containers are summed",
+			spec:       corev1.PodSpec{Containers: []corev1.Container{container("100m", "64Mi"), container("200m", "64Mi")}},
+			wantCPU:    "300m",
+			wantMemory: "128Mi",
+		},
+		{
+			name: "largest init container wins per resource",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{container("1", "32Mi"), container("100m", "256Mi")},
+				Containers:     []corev1.Container{container("200m", "64Mi")},
+			},
+			wantCPU:    "1",
+			wantMemory: "256Mi",
+		},
+		{
+			name: "sidecars are added to app containers",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{sidecar("100m", "32Mi"), container("50m", "16Mi")},
+				Containers:     []corev1.Container{container("200m", "64Mi")},
+			},
+			wantCPU:    "300m",
+			wantMemory: "96Mi",
+		},
+		{
+			name: "init containers run next to earlier sidecars only",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{sidecar("100m", "32Mi"), container("500m", "64Mi"), sidecar("100m", "32Mi")},
+				Containers:     []corev1.Container{container("100m", "32Mi")},
+			},
+			wantCPU:    "600m",
+			wantMemory: "96Mi",
+		},
+		{
+			name: "overhead is added",
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{container("100m", "64Mi")},
+				Overhead:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")},
+			},
+			wantCPU:    "110m",
+			wantMemory: "64Mi",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := podRequests(&corev1.Pod{Spec: tt.spec})
+			if q := got[corev1.ResourceCPU]; q.Cmp(resource.MustParse(tt.wantCPU)) != 0 {
+				t.Errorf("cpu = %s, want %s", q.String(), tt.wantCPU)
+			}
+			if q := got[corev1.ResourceMemory]; q.Cmp(resource.MustParse(tt.wantMemory)) != 0 {
+				t.Errorf("memory = %s, want %s", q.String(), tt.wantMemory)
+			}
+		})
+	}
+}
@@ -3,33 +3,79 @@ package k8sutils
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"sync"
 
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/transport"
 )
 
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
 type Clientset struct {
 	clientset *kubernetes.Clientset
+	config    *rest.Config
+	logger    Logger
+	namespace string
+	// collisions is optional, see SetNameCollisionMetrics
+	collisions NameCollisionMetrics
+
+	// versionMu guards serverVersion, which is fetched lazily and cached
+	versionMu     sync.Mutex
+	serverVersion *version.Info
 }
 
 var (
-	cli              *Clientset
-	once             sync.Once
-	serverVersion    *version.Info
-	currentNamespace string
+	cli            *Clientset
+	initErr        error
+	once           sync.Once
+	defaultOptions []ClientOption
 )
 
-func NewClientSet() (*kubernetes.Clientset, error) {
+// ClientOption customizes the rest.Config a client is built from.
+type ClientOption func(config *rest.Config)
+
+// WithTransportWrapper wraps the HTTP transport of the client, e.g. to add headers, audit logging
+// or request recording. Wrappers are applied in the order the options are given.
+func WithTransportWrapper(fn transport.WrapperFunc) ClientOption {
+	return func(config *rest.Config) {
+		config.Wrap(fn)
+	}
+}
+
+// WithImpersonation makes every request of the client act as the given user, groups or service account,
+// so RBAC is evaluated (and audited) for that identity.
+func WithImpersonation(impersonate rest.ImpersonationConfig) ClientOption {
+	return func(config *rest.Config) {
+		config.Impersonate = impersonate
+	}
+}
+
+// WithServiceAccountImpersonation is WithImpersonation for the service account namespace/name.
+func WithServiceAccountImpersonation(namespace, name string) ClientOption {
+	return WithImpersonation(rest.ImpersonationConfig{
+		UserName: "system:serviceaccount:" + namespace + ":" + name,
+	})
+}
+
+// SetDefaultClientOptions sets the options GetClientset builds its client with.
+// It must be called before the first GetClientset call to have an effect.
+func SetDefaultClientOptions(opts ...ClientOption) {
+	defaultOptions = opts
+}
+
+// NewRestConfig loads the in-cluster config, falling back to the kubeconfig from $KUBECONFIG or ~/.kube/config.
+func NewRestConfig() (*rest.Config, error) {
 	config, err := rest.InClusterConfig()
 	if err != nil {
 		var configPath string
 		if p := os.Getenv(clientcmd.RecommendedConfigPathEnvVar); len(p) > 0 {
 			configPath = p
 		} else {
-			p = clientcmd.RecommendedHomeFile
+			configPath = clientcmd.RecommendedHomeFile
 		}
 		config, err = clientcmd.BuildConfigFromFlags("", configPath)
 	}
@@ -38,62 +84,181 @@ func NewClientSet() (*kubernetes.Clientset, error) {
 		err = fmt.Errorf("error building kubeconfig: %w", err)
 		return nil, err
 	}
-	return kubernetes.NewForConfig(config)
+	return config, nil
+}
+
+func NewClientSet(opts ...ClientOption) (*kubernetes.Clientset, error) {
+	config, err := NewRestConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(applyOptions(config, opts))
+}
+
+// NewClientsetForConfig builds a Clientset from config. Unlike GetClientset it is not shared,
+// so several clients with different options can coexist.
+func NewClientsetForConfig(config *rest.Config, opts ...ClientOption) (*Clientset, error) {
+	config = applyOptions(config, opts)
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Kubernetes client: %w", err)
+	}
+
+	kc := &Clientset{
+		clientset: clientset,
+		config:    config,
+		logger:    defaultLogger,
+	}
+	kc.namespace = kc.readNamespace(serviceAccountNamespaceFile)
+	return kc, nil
+}
+
+// NewClientsetFromToken builds a Clientset without a kubeconfig from an API server host, a CA bundle file
+// and a bearer token file, e.g. a projected service account token. The token file is re-read periodically
+// so rotated tokens are picked up. The namespace is read from a "namespace" file next to the token when present.
+func NewClientsetFromToken(host, caFile, tokenFile string, opts ...ClientOption) (*Clientset, error) {
+	if host == "" || tokenFile == "" {
+		return nil, fmt.Errorf("error building config from token: host and token file are required")
+	}
+	if _, err := os.Stat(tokenFile); err != nil {
+		return nil, fmt.Errorf("error building config from token: %w", err)
+	}
+
+	config := &rest.Config{
+		Host:            host,
+		BearerTokenFile: tokenFile,
+		TLSClientConfig: rest.TLSClientConfig{CAFile: caFile},
+	}
+	kc, err := NewClientsetForConfig(config, opts...)
+	if err != nil {
+		return nil, err
+	}
+	kc.namespace = kc.readNamespace(filepath.Join(filepath.Dir(tokenFile), "namespace"))
+	return kc, nil
 }
 
+func applyOptions(config *rest.Config, opts []ClientOption) *rest.Config {
+	config = rest.CopyConfig(config)
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// GetClientset returns the shared Clientset, building it on the first call. A failure to load the config
+// or build the client is returned on every call.
 func GetClientset() (*Clientset, error) {
 	once.Do(func() {
-		var (
-			err       error
-			clientset *kubernetes.Clientset
-		)
-		cli = &Clientset{}
+		c := &Clientset{logger: defaultLogger}
+
+		// try to read current namespace
+		c.namespace = c.readNamespace(serviceAccountNamespaceFile)
 
-		clientset, err = NewClientSet()
+		config, err := NewRestConfig()
 		if err != nil {
-			err = fmt.Errorf("error creating Kubernetes client: %w", err)
+			initErr = fmt.Errorf("error creating Kubernetes client: %w", err)
 			return
 		}
 
-		cli.clientset = clientset
-
-		serverVersion, err = cli.clientset.Discovery().ServerVersion()
+		c.config = applyOptions(config, defaultOptions)
+		c.clientset, err = kubernetes.NewForConfig(c.config)
 		if err != nil {
-			err = fmt.Errorf("error getting server version: %w", err)
+			initErr = fmt.Errorf("error creating Kubernetes client: %w", err)
 			return
 		}
 
-		// try to read current namespace
-		if b, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace"); err != nil {
-			currentNamespace = "default" // if error , set currentNamespace -> default
-			fmt.Println("default namespace")
-		} else {
-			currentNamespace = string(b)
+		// a transient API server error must not break the shared client for good,
+		// GetServerVersion fetches the version lazily when it is missing
+		if c.serverVersion, err = c.clientset.Discovery().ServerVersion(); err != nil {
+			c.serverVersion = nil
+			c.Logger().Error(err, "error getting server version, will retry on demand")
 		}
+
+		cli = c
 	})
 
+	if initErr != nil {
+		return nil, initErr
+	}
 	return cli, nil
 }
 
+// readNamespace reads the namespace from a service account namespace file, falling back to default.
+func (kc *Clientset) readNamespace(path string) string {
+	b, err := os.ReadFile(path)
+	if err != nil || len(b) == 0 {
+		kc.Logger().Debug("service account namespace not found, using default namespace", "err", err)
+		return "default"
+	}
+	return string(b)
+}
+
 func (kc *Clientset) GetServerVersion() (string, error) {
-	if serverVersion != nil {
-		v := serverVersion
-		_ = v
-		return serverVersion.String(), nil
+	kc.versionMu.Lock()
+	defer kc.versionMu.Unlock()
+
+	if kc.serverVersion != nil {
+		return kc.serverVersion.String(), nil
 	}
 
 	v, err := kc.clientset.Discovery().ServerVersion()
 	if err != nil {
 		return "", err
 	}
-	serverVersion = v
-	return serverVersion.String(), nil
+	kc.serverVersion = v
+	return kc.serverVersion.String(), nil
 }
 
 func (kc *Clientset) GetNamespace() string {
-	return currentNamespace
+	return kc.namespace
 }
 
 func (kc *Clientset) GetClientSet() *kubernetes.Clientset {
 	return kc.clientset
 }
+
+// RestConfig returns a copy of the config the client was built with.
+func (kc *Clientset) RestConfig() *rest.Config {
+	return rest.CopyConfig(kc.config)
+}
+
+// Impersonate returns a new Clientset built from the same config that acts as the given identity.
+func (kc *Clientset) Impersonate(impersonate rest.ImpersonationConfig) (*Clientset, error) {
+	if kc.config == nil {
+		return nil, fmt.Errorf("error impersonating %q: client has no rest config", impersonate.UserName)
+	}
+	ic, err := NewClientsetForConfig(kc.config, WithImpersonation(impersonate))
+	if err != nil {
+		return nil, err
+	}
+	ic.logger = kc.logger
+	ic.namespace = kc.namespace
+	ic.collisions = kc.collisions
+	return ic, nil
+}
+
+func (kc *Clientset) Logger() Logger {
+	if kc.logger == nil {
+		return defaultLogger
+	}
+	return kc.logger
+}
+
+func (kc *Clientset) SetLogger(l Logger) {
+	if l == nil {
+		l = NopLogger()
+	}
+	kc.logger = l
+}
+
+// SetNameCollisionMetrics reports the name collisions of the generated-name helpers to m, nil disables it.
+// A *RequestStats can be passed here and to WithRequestMetrics to relate collisions to create requests.
+func (kc *Clientset) SetNameCollisionMetrics(m NameCollisionMetrics) {
+	kc.collisions = m
+}
+
+func (kc *Clientset) observeNameCollision(resource string) {
+	if kc.collisions != nil {
+		kc.collisions.ObserveNameCollision(resource)
+	}
+}
@@ -0,0 +1,54 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// MaxNameLength is the maximum length of a DNS-1123 label, which most resource names must fit into.
+	MaxNameLength = 63
+
+	randomSuffixLength = 5
+	hashSuffixLength   = 10
+)
+
+// GenerateName2Name turns a generateName style prefix into a concrete name by
+// appending a random suffix, truncating the prefix so the result fits MaxNameLength.
+func GenerateName2Name(generateName string) string {
+	return joinSuffix(generateName, RandLowerStr(randomSuffixLength))
+}
+
+// NameWithULID appends a lowercase ULID to prefix. Names generated this way sort by creation time.
+func NameWithULID(prefix string) string {
+	return joinSuffix(prefix, strings.ToLower(NewULID()))
+}
+
+// NameWithKSUID appends a KSUID to prefix, lowercased so it stays a valid resource name.
+func NameWithKSUID(prefix string) string {
+	return joinSuffix(prefix, strings.ToLower(NewKSUID()))
+}
+
+// NameFromHash returns a deterministic name built from prefix and a hash of parts,
+// so reruns with the same inputs produce the same name.
+func NameFromHash(prefix string, parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+	return joinSuffix(prefix, sum[:hashSuffixLength])
+}
+
+func joinSuffix(prefix, suffix string) string {
+	prefix = strings.TrimSuffix(prefix, "-")
+	if prefix == "" {
+		return suffix
+	}
+	if limit := MaxNameLength - len(suffix) - 1; len(prefix) > limit {
+		prefix = strings.TrimRight(prefix[:limit], "-.")
+	}
+	return prefix + "-" + suffix
+}
@@ -0,0 +1,71 @@
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJoinSuffix(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		suffix string
+		want   string
+	}{
+		{name: "short prefix", prefix: "job", suffix: "abcde", want: "job-abcde"},
+		{name: "trailing dash is not doubled", prefix: "job-", suffix: "abcde", want: "job-abcde"},
+		{name: "empty prefix", prefix: "", suffix: "abcde", want: "abcde"},
+		{
+			name:   "long prefix is truncated",
+			prefix: strings.Repeat("a", 70),
+			suffix: "abcde",
+			want:   strings.Repeat("a", 57) + "-abcde",
+		},
+		{
+			name:   "truncation ending in dash is trimmed",
+			prefix: strings.Repeat("a", 56) + "-bbbbbbbb",
+			suffix: "abcde",
+			want:   strings.Repeat("a", 56) + "-abcde",
+		},
+		{
+			name:   "truncation ending in dot is trimmed",
+			prefix: strings.Repeat("a", 55) + ".-bbbbbbbb",
+			suffix: "abcde",
+			want:   strings.Repeat("a", 55) + "-abcde",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := joinSuffix(tt.prefix, tt.suffix)
+			if got != tt.want {
+				t.Errorf("joinSuffix(%q, %q) = %q, want %q", tt.prefix, tt.suffix, got, tt.want)
+			}
+			if len(got) > MaxNameLength {
+				t.Errorf("joinSuffix(%q, %q) has length %d, want <= %d", tt.prefix, tt.suffix, len(got), MaxNameLength)
+			}
+		})
+	}
+}
+
+func TestNameFromHash(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  []string
+		equal bool
+	}{
+		{name: "same input", a: []string{"ns", "task"}, b: []string{"ns", "task"}, equal: true},
+		{name: "different input", a: []string{"ns", "task"}, b: []string{"ns", "other"}, equal: false},
+		{name: "parts are delimited", a: []string{"ab", "c"}, b: []string{"a", "bc"}, equal: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := NameFromHash("job", tt.a...), NameFromHash("job", tt.b...)
+			if (a == b) != tt.equal {
+				t.Errorf("NameFromHash(%v) = %q, NameFromHash(%v) = %q, want equal=%v", tt.a, a, tt.b, b, tt.equal)
+			}
+			if !strings.HasPrefix(a, "job-") || len(a) != len("job-")+hashSuffixLength {
+				t.Errorf("NameFromHash(%v) = %q, want job- followed by %d hash chars", tt.a, a, hashSuffixLength)
+			}
+		})
+	}
+}
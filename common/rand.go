@@ -0,0 +1,26 @@
+package common
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+const lowerAlphanum = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// RandLowerStr returns a random string of length n made of lowercase letters and digits,
+// safe to use in DNS-1123 names.
+func RandLowerStr(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	b := make([]byte, n)
+	alphabetLen := big.NewInt(int64(len(lowerAlphanum)))
+	for i := range b {
+		idx, err := rand.Int(rand.Reader, alphabetLen)
+		if err != nil {
+			panic(err)
+		}
+		b[i] = lowerAlphanum[idx.Int64()]
+	}
+	return string(b)
+}
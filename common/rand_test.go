@@ -0,0 +1,27 @@
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRandLowerStr(t *testing.T) {
+	tests := []struct {
+		n    int
+		want int
+	}{
+		{n: -1, want: 0},
+		{n: 0, want: 0},
+		{n: 5, want: 5},
+		{n: 64, want: 64},
+	}
+	for _, tt := range tests {
+		got := RandLowerStr(tt.n)
+		if len(got) != tt.want {
+			t.Errorf("RandLowerStr(%d) has length %d, want %d", tt.n, len(got), tt.want)
+		}
+		if i := strings.IndexFunc(got, func(r rune) bool { return !strings.ContainsRune(lowerAlphanum, r) }); i >= 0 {
+			t.Errorf("RandLowerStr(%d) = %q contains %q outside the lowercase alphanumeric charset", tt.n, got, got[i])
+		}
+	}
+}
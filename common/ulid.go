@@ -0,0 +1,69 @@
+package common
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"math/big"
+	"time"
+)
+
+const (
+	crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	base62    = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// ksuidEpoch is the KSUID custom epoch (2014-05-13T16:53:20Z).
+	ksuidEpoch     = 1400000000
+	ksuidStringLen = 27
+)
+
+// NewULID returns a 26 character ULID: 48 bits of millisecond timestamp followed by 80 random bits.
+func NewULID() string {
+	return ulidAt(time.Now())
+}
+
+func ulidAt(t time.Time) string {
+	var id [16]byte
+	ms := uint64(t.UnixMilli())
+	for i := 5; i >= 0; i-- {
+		id[i] = byte(ms)
+		ms >>= 8
+	}
+	mustRead(id[6:])
+
+	// 128 bits encoded 5 bits at a time, the first char carries the top 3 bits
+	n := new(big.Int).SetBytes(id[:])
+	out := make([]byte, 26)
+	mask := big.NewInt(31)
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = crockford[new(big.Int).And(n, mask).Int64()]
+		n.Rsh(n, 5)
+	}
+	return string(out)
+}
+
+// NewKSUID returns a 27 character KSUID: a 32 bit timestamp in seconds followed by 128 random bits, base62 encoded.
+func NewKSUID() string {
+	return ksuidAt(time.Now())
+}
+
+func ksuidAt(t time.Time) string {
+	var id [20]byte
+	binary.BigEndian.PutUint32(id[:4], uint32(t.Unix()-ksuidEpoch))
+	mustRead(id[4:])
+
+	n := new(big.Int).SetBytes(id[:])
+	base := big.NewInt(62)
+	mod := new(big.Int)
+	out := make([]byte, ksuidStringLen)
+	for i := len(out) - 1; i >= 0; i-- {
+		n.DivMod(n, base, mod)
+		out[i] = base62[mod.Int64()]
+	}
+	return string(out)
+}
+
+func mustRead(b []byte) {
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,67 @@
+package common
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestULIDAt(t *testing.T) {
+	tests := []struct {
+		ms         int64
+		wantPrefix string
+	}{
+		// test vector from the ULID spec
+		{ms: 1469918176385, wantPrefix: "01ARYZ6S41"},
+		{ms: 0, wantPrefix: "0000000000"},
+	}
+	for _, tt := range tests {
+		got := ulidAt(time.UnixMilli(tt.ms))
+		if len(got) != 26 {
+			t.Errorf("ulidAt(%d) = %q has length %d, want 26", tt.ms, got, len(got))
+		}
+		if !strings.HasPrefix(got, tt.wantPrefix) {
+			t.Errorf("ulidAt(%d) = %q, want prefix %q", tt.ms, got, tt.wantPrefix)
+		}
+		if i := strings.IndexFunc(got, func(r rune) bool { return !strings.ContainsRune(crockford, r) }); i >= 0 {
+			t.Errorf("ulidAt(%d) = %q contains non Crockford char %q", tt.ms, got, got[i])
+		}
+	}
+}
+
+func TestKSUIDAt(t *testing.T) {
+	tests := []struct {
+		name string
+		at   time.Time
+	}{
+		{name: "epoch", at: time.Unix(ksuidEpoch, 0)},
+		{name: "spec example time", at: time.Unix(ksuidEpoch+107608047, 0)},
+		{name: "now", at: time.Unix(time.Now().Unix(), 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ksuidAt(tt.at)
+			if len(got) != ksuidStringLen {
+				t.Fatalf("ksuidAt() = %q has length %d, want %d", got, len(got), ksuidStringLen)
+			}
+			if ts := decodeKSUIDTimestamp(t, got); ts != tt.at.Unix()-ksuidEpoch {
+				t.Errorf("ksuidAt() = %q encodes timestamp %d, want %d", got, ts, tt.at.Unix()-ksuidEpoch)
+			}
+		})
+	}
+}
+
+func decodeKSUIDTimestamp(t *testing.T, s string) int64 {
+	t.Helper()
+	n := new(big.Int)
+	base := big.NewInt(62)
+	for _, c := range s {
+		idx := strings.IndexRune(base62, c)
+		if idx < 0 {
+			t.Fatalf("%q contains non base62 char %q", s, c)
+		}
+		n.Mul(n, base).Add(n, big.NewInt(int64(idx)))
+	}
+	return n.Rsh(n, 128).Int64()
+}
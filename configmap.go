@@ -0,0 +1,75 @@
+package k8sutils
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/linlanniao/k8sutils/common"
+)
+
+func (kc *Clientset) CreateConfigMap(ctx context.Context, cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	return kc.clientset.CoreV1().ConfigMaps(cm.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+}
+
+func (kc *Clientset) GetConfigMap(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	return kc.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (kc *Clientset) DeleteConfigMap(ctx context.Context, namespace, name string) error {
+	return kc.clientset.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// CreateConfigMapIfNotExists is the ConfigMap counterpart of CreateJobIfNotExists.
+func (kc *Clientset) CreateConfigMapIfNotExists(ctx context.Context, cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	hash := SetConfigMapHash(cm)
+	created, err := kc.CreateConfigMap(ctx, cm)
+	if err == nil {
+		return created, nil
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+
+	existing, err := kc.GetConfigMap(ctx, cm.Namespace, cm.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error getting existing configmap %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+	if existing.Annotations[SpecHashAnnotation] != hash {
+		return existing, fmt.Errorf("configmap %s/%s: %w", cm.Namespace, cm.Name, ErrSpecHashMismatch)
+	}
+	return existing, nil
+}
+
+const maxGenerateNameAttempts = 5
+
+// CreateConfigMapWithGeneratedName names the configmap from cm.GenerateName plus a random suffix and creates it.
+// On a name collision a new suffix is drawn, up to maxGenerateNameAttempts times. Collisions are reported
+// to the NameCollisionMetrics set with SetNameCollisionMetrics.
+func (kc *Clientset) CreateConfigMapWithGeneratedName(ctx context.Context, cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	if cm.GenerateName == "" {
+		return nil, fmt.Errorf("error creating configmap: GenerateName is required")
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxGenerateNameAttempts; attempt++ {
+		cm.Name = common.GenerateName2Name(cm.GenerateName)
+
+		var created *corev1.ConfigMap
+		created, err = kc.CreateConfigMap(ctx, cm)
+		if err == nil {
+			return created, nil
+		}
+		if !apierrors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		kc.observeNameCollision("configmaps")
+		kc.Logger().Info("configmap name collision, regenerating name",
+			"namespace", cm.Namespace, "name", cm.Name, "attempt", attempt)
+	}
+	return nil, fmt.Errorf("error creating configmap %s/%s* after %d name collisions: %w",
+		cm.Namespace, cm.GenerateName, maxGenerateNameAttempts, err)
+}
@@ -0,0 +1,111 @@
+package k8sutils
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type DriftReason string
+
+const (
+	DriftMissing     DriftReason = "Missing"
+	DriftUnhashed    DriftReason = "Unhashed"
+	DriftSpecChanged DriftReason = "SpecChanged"
+	// DriftModified means the live object no longer matches the hash it was created with,
+	// i.e. it was edited in the cluster.
+	DriftModified DriftReason = "Modified"
+)
+
+type Drift struct {
+	Kind        string
+	Namespace   string
+	Name        string
+	Reason      DriftReason
+	LiveHash    string
+	DesiredHash string
+}
+
+func (d Drift) String() string {
+	return fmt.Sprintf("%s %s/%s: %s (live=%q desired=%q)", d.Kind, d.Namespace, d.Name, d.Reason, d.LiveHash, d.DesiredHash)
+}
+
+// JobDrift compares the live job with the desired one, returning nil when they match.
+// The live job spec is defaulted by the API server, so the comparison relies on SpecHashAnnotation.
+func JobDrift(live, desired *batchv1.Job) *Drift {
+	d := &Drift{Kind: "Job", Namespace: desired.Namespace, Name: desired.Name, DesiredHash: JobSpecHash(desired)}
+	if live == nil {
+		d.Reason = DriftMissing
+		return d
+	}
+	d.LiveHash = live.Annotations[SpecHashAnnotation]
+	switch {
+	case d.LiveHash == "":
+		d.Reason = DriftUnhashed
+	case d.LiveHash != d.DesiredHash:
+		d.Reason = DriftSpecChanged
+	default:
+		return nil
+	}
+	return d
+}
+
+// ConfigMapDrift compares the live configmap with the desired one, returning nil when they match.
+// Unlike jobs, configmap data is not defaulted, so in-cluster edits are detected as well.
+func ConfigMapDrift(live, desired *corev1.ConfigMap) *Drift {
+	d := &Drift{Kind: "ConfigMap", Namespace: desired.Namespace, Name: desired.Name, DesiredHash: ConfigMapHash(desired)}
+	if live == nil {
+		d.Reason = DriftMissing
+		return d
+	}
+	d.LiveHash = live.Annotations[SpecHashAnnotation]
+	switch {
+	case d.LiveHash == "":
+		d.Reason = DriftUnhashed
+	case d.LiveHash != d.DesiredHash:
+		d.Reason = DriftSpecChanged
+	case ConfigMapHash(live) != d.LiveHash:
+		d.Reason = DriftModified
+	default:
+		return nil
+	}
+	return d
+}
+
+// DetectDrift fetches the live counterpart of every desired Job or ConfigMap and reports the ones that diverge.
+func (kc *Clientset) DetectDrift(ctx context.Context, desired ...runtime.Object) ([]Drift, error) {
+	var drifts []Drift
+	for _, obj := range desired {
+		var d *Drift
+		switch o := obj.(type) {
+		case *batchv1.Job:
+			live, err := kc.GetJob(ctx, o.Namespace, o.Name)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("error getting job %s/%s: %w", o.Namespace, o.Name, err)
+			}
+			if err != nil {
+				live = nil
+			}
+			d = JobDrift(live, o)
+		case *corev1.ConfigMap:
+			live, err := kc.GetConfigMap(ctx, o.Namespace, o.Name)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("error getting configmap %s/%s: %w", o.Namespace, o.Name, err)
+			}
+			if err != nil {
+				live = nil
+			}
+			d = ConfigMapDrift(live, o)
+		default:
+			return nil, fmt.Errorf("drift detection is not supported for %T", obj)
+		}
+		if d != nil {
+			drifts = append(drifts, *d)
+		}
+	}
+	return drifts, nil
+}
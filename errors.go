@@ -0,0 +1,20 @@
+package k8sutils
+
+import "errors"
+
+var (
+	// ErrNoPodsYet is returned when a Job exists but its controller has not created any pod yet.
+	ErrNoPodsYet = errors.New("no pods created for job yet")
+
+	// ErrSpecHashMismatch is returned when an object with the same name exists but was created from a different spec.
+	ErrSpecHashMismatch = errors.New("existing object was created from a different spec")
+
+	// ErrUnauthenticated is returned when a TokenReview does not authenticate the token.
+	ErrUnauthenticated = errors.New("token is not authenticated")
+
+	// ErrNoDefaultStorageClass is returned when no storage class is annotated as the cluster default.
+	ErrNoDefaultStorageClass = errors.New("no default storage class")
+
+	// ErrNodeNotReady is returned when a node is not Ready or is under resource pressure.
+	ErrNodeNotReady = errors.New("node is not ready")
+)
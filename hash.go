@@ -0,0 +1,56 @@
+package k8sutils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SpecHashAnnotation records the hash of the spec an object was created from.
+const SpecHashAnnotation = "spec.kbatch/hash"
+
+func JobSpecHash(job *batchv1.Job) string {
+	return hashOf(job.Spec)
+}
+
+func ConfigMapHash(cm *corev1.ConfigMap) string {
+	return hashOf(struct {
+		Data       map[string]string `json:"data,omitempty"`
+		BinaryData map[string][]byte `json:"binaryData,omitempty"`
+		Immutable  *bool             `json:"immutable,omitempty"`
+	}{cm.Data, cm.BinaryData, cm.Immutable})
+}
+
+// SetJobSpecHash stores JobSpecHash in the job's SpecHashAnnotation.
+func SetJobSpecHash(job *batchv1.Job) string {
+	h := JobSpecHash(job)
+	setAnnotation(&job.ObjectMeta, SpecHashAnnotation, h)
+	return h
+}
+
+// SetConfigMapHash stores ConfigMapHash in the configmap's SpecHashAnnotation.
+func SetConfigMapHash(cm *corev1.ConfigMap) string {
+	h := ConfigMapHash(cm)
+	setAnnotation(&cm.ObjectMeta, SpecHashAnnotation, h)
+	return h
+}
+
+func hashOf(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+func setAnnotation(meta *metav1.ObjectMeta, key, value string) {
+	if meta.Annotations == nil {
+		meta.Annotations = make(map[string]string)
+	}
+	meta.Annotations[key] = value
+}
@@ -0,0 +1,92 @@
+package k8sutils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+func (kc *Clientset) CreateHPA(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	return kc.clientset.AutoscalingV2().HorizontalPodAutoscalers(hpa.Namespace).Create(ctx, hpa, metav1.CreateOptions{})
+}
+
+func (kc *Clientset) GetHPA(ctx context.Context, namespace, name string) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	return kc.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (kc *Clientset) DeleteHPA(ctx context.Context, namespace, name string) error {
+	return kc.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+func (kc *Clientset) ListHPA(ctx context.Context, namespace string, selector labels.Selector) (*autoscalingv2.HorizontalPodAutoscalerList, error) {
+	opts := metav1.ListOptions{}
+	if selector != nil {
+		opts.LabelSelector = selector.String()
+	}
+	return kc.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, opts)
+}
+
+// PatchHPAReplicas changes the replica bounds of the HPA, a nil bound is left untouched.
+func (kc *Clientset) PatchHPAReplicas(ctx context.Context, namespace, name string, minReplicas, maxReplicas *int32) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	spec := map[string]any{}
+	if minReplicas != nil {
+		spec["minReplicas"] = *minReplicas
+	}
+	if maxReplicas != nil {
+		spec["maxReplicas"] = *maxReplicas
+	}
+	patch, err := json.Marshal(map[string]any{"spec": spec})
+	if err != nil {
+		return nil, err
+	}
+	hpa, err := kc.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error patching replicas of hpa %s/%s: %w", namespace, name, err)
+	}
+	return hpa, nil
+}
+
+// PatchHPAUtilizationTarget sets the average utilization target of a resource metric (cpu, memory)
+// on the HPA, adding the metric when it is not present yet.
+func (kc *Clientset) PatchHPAUtilizationTarget(ctx context.Context, namespace, name string, resourceName corev1.ResourceName, averageUtilization int32) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	var updated *autoscalingv2.HorizontalPodAutoscaler
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		hpa, err := kc.GetHPA(ctx, namespace, name)
+		if err != nil {
+			return err
+		}
+
+		target := autoscalingv2.MetricTarget{
+			Type:               autoscalingv2.UtilizationMetricType,
+			AverageUtilization: &averageUtilization,
+		}
+		found := false
+		for i := range hpa.Spec.Metrics {
+			m := &hpa.Spec.Metrics[i]
+			if m.Type == autoscalingv2.ResourceMetricSourceType && m.Resource != nil && m.Resource.Name == resourceName {
+				m.Resource.Target = target
+				found = true
+			}
+		}
+		if !found {
+			hpa.Spec.Metrics = append(hpa.Spec.Metrics, autoscalingv2.MetricSpec{
+				Type:     autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{Name: resourceName, Target: target},
+			})
+		}
+
+		updated, err = kc.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).Update(ctx, hpa, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error updating %s target of hpa %s/%s: %w", resourceName, namespace, name, err)
+	}
+	return updated, nil
+}
@@ -0,0 +1,29 @@
+package k8sutils
+
+import (
+	"context"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func (kc *Clientset) CreateIngress(ctx context.Context, ing *networkingv1.Ingress) (*networkingv1.Ingress, error) {
+	return kc.clientset.NetworkingV1().Ingresses(ing.Namespace).Create(ctx, ing, metav1.CreateOptions{})
+}
+
+func (kc *Clientset) GetIngress(ctx context.Context, namespace, name string) (*networkingv1.Ingress, error) {
+	return kc.clientset.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (kc *Clientset) DeleteIngress(ctx context.Context, namespace, name string) error {
+	return kc.clientset.NetworkingV1().Ingresses(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+func (kc *Clientset) ListIngress(ctx context.Context, namespace string, selector labels.Selector) (*networkingv1.IngressList, error) {
+	opts := metav1.ListOptions{}
+	if selector != nil {
+		opts.LabelSelector = selector.String()
+	}
+	return kc.clientset.NetworkingV1().Ingresses(namespace).List(ctx, opts)
+}
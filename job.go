@@ -0,0 +1,134 @@
+package k8sutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/linlanniao/k8sutils/waitutil"
+)
+
+func (kc *Clientset) CreateJob(ctx context.Context, job *batchv1.Job) (*batchv1.Job, error) {
+	return kc.clientset.BatchV1().Jobs(job.Namespace).Create(ctx, job, metav1.CreateOptions{})
+}
+
+func (kc *Clientset) GetJob(ctx context.Context, namespace, name string) (*batchv1.Job, error) {
+	return kc.clientset.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// DeleteJob deletes the job. A nil opts uses foreground propagation so the job's pods are removed first.
+func (kc *Clientset) DeleteJob(ctx context.Context, namespace, name string, opts *metav1.DeleteOptions) error {
+	if opts == nil {
+		policy := metav1.DeletePropagationForeground
+		opts = &metav1.DeleteOptions{PropagationPolicy: &policy}
+	}
+	return kc.clientset.BatchV1().Jobs(namespace).Delete(ctx, name, *opts)
+}
+
+// CreateJobIfNotExists creates the job, stamping it with SpecHashAnnotation. If a job with the same name
+// already exists and was created from the same spec it is returned instead, so retried creates are safe.
+// A different spec yields ErrSpecHashMismatch together with the existing job.
+func (kc *Clientset) CreateJobIfNotExists(ctx context.Context, job *batchv1.Job) (*batchv1.Job, error) {
+	hash := SetJobSpecHash(job)
+	created, err := kc.CreateJob(ctx, job)
+	if err == nil {
+		return created, nil
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+
+	existing, err := kc.GetJob(ctx, job.Namespace, job.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error getting existing job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	if existing.Annotations[SpecHashAnnotation] != hash {
+		return existing, fmt.Errorf("job %s/%s: %w", job.Namespace, job.Name, ErrSpecHashMismatch)
+	}
+	return existing, nil
+}
+
+// ReplaceJob creates the job, first deleting an existing job with the same name (foreground) and waiting
+// until it and its pods are gone. Bound the wait through ctx.
+func (kc *Clientset) ReplaceJob(ctx context.Context, job *batchv1.Job) (*batchv1.Job, error) {
+	created, err := kc.CreateJob(ctx, job)
+	if err == nil || !apierrors.IsAlreadyExists(err) {
+		return created, err
+	}
+
+	if err := kc.DeleteJob(ctx, job.Namespace, job.Name, nil); err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("error deleting existing job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	lw := waitutil.JobListWatch(ctx, kc.clientset, job.Namespace, job.Name)
+	if err := waitutil.WaitForDeletion(ctx, lw, &batchv1.Job{}); err != nil {
+		return nil, fmt.Errorf("error replacing job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+
+	created, err = kc.CreateJob(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("error creating replacement job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	return created, nil
+}
+
+type WaitForJobOptions struct {
+	// Timeout bounds the wait, zero waits until ctx is done.
+	Timeout time.Duration
+}
+
+type JobCompletion struct {
+	Outcome waitutil.JobOutcome
+	Status  batchv1.JobStatus
+	// Reason and Message come from the job's Complete or Failed condition.
+	Reason  string
+	Message string
+	// PodMessages maps "pod/container" to the termination message or reason of terminated containers.
+	PodMessages map[string]string
+}
+
+// WaitForJobCompletion watches the job until it completes or fails and returns how it ended,
+// including the termination messages of its pods. A failed job is reported through Outcome, not as an error.
+func (kc *Clientset) WaitForJobCompletion(ctx context.Context, namespace, jobName string, opts WaitForJobOptions) (*JobCompletion, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	result, err := waitutil.WaitForJobComplete(ctx, kc.clientset, namespace, jobName)
+	if err != nil {
+		return nil, err
+	}
+	completion := &JobCompletion{
+		Outcome:     result.Outcome,
+		Status:      result.Job.Status,
+		Reason:      result.Reason,
+		Message:     result.Message,
+		PodMessages: make(map[string]string),
+	}
+
+	pods, err := kc.GetPodsFromJob(ctx, namespace, jobName)
+	// the job may already be gone, e.g. removed by its TTL controller
+	if err != nil && !errors.Is(err, ErrNoPodsYet) && !apierrors.IsNotFound(err) {
+		return completion, err
+	}
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			t := cs.State.Terminated
+			if t == nil {
+				continue
+			}
+			msg := t.Message
+			if msg == "" {
+				msg = fmt.Sprintf("%s (exit code %d)", t.Reason, t.ExitCode)
+			}
+			completion.PodMessages[pod.Name+"/"+cs.Name] = msg
+		}
+	}
+	return completion, nil
+}
@@ -0,0 +1,88 @@
+package k8sutils
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// DefaultKubectlImageRepository publishes kubectl images tagged with the kubectl version, e.g. alpine/k8s:1.29.2.
+const DefaultKubectlImageRepository = "alpine/k8s"
+
+// DefaultKubectlImageTags maps a server "major.minor" version to a known-good tag of
+// DefaultKubectlImageRepository. Any patch of a minor works against that minor's API server,
+// so the server's own patch (often a vendor build like v1.29.10-eks-...) is never used as a tag.
+var DefaultKubectlImageTags = map[string]string{
+	"1.25": "1.25.16",
+	"1.26": "1.26.15",
+	"1.27": "1.27.16",
+	"1.28": "1.28.15",
+	"1.29": "1.29.2",
+	"1.30": "1.30.2",
+	"1.31": "1.31.0",
+}
+
+type KubectlImageOptions struct {
+	// Repository replaces DefaultKubectlImageRepository for tags from DefaultKubectlImageTags.
+	Repository string
+	// Images maps a server "major.minor" version such as "1.29" to a full image reference and
+	// takes precedence over the defaults.
+	Images map[string]string
+	// Fallback is returned when no image is known for the server version, not even one minor away.
+	// Leave it empty to get an error instead.
+	Fallback string
+}
+
+// KubectlImage selects a kubectl image matching the API server's minor version, avoiding
+// kubectl/apiserver skew. Without options it picks from DefaultKubectlImageTags. When the exact
+// minor is unknown an image one minor older or newer is used, which kubectl's version skew policy supports.
+func (kc *Clientset) KubectlImage(opts KubectlImageOptions) (string, error) {
+	raw, err := kc.GetServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("error getting server version: %w", err)
+	}
+	image, err := kubectlImageFor(raw, opts)
+	if err != nil {
+		return "", err
+	}
+	if image == opts.Fallback {
+		kc.Logger().Info("no kubectl image for server version, using fallback", "serverVersion", raw, "image", image)
+	}
+	return image, nil
+}
+
+func kubectlImageFor(serverVersion string, opts KubectlImageOptions) (string, error) {
+	v, err := version.ParseGeneric(serverVersion)
+	if err != nil {
+		return "", fmt.Errorf("error parsing server version %q: %w", serverVersion, err)
+	}
+	repository := opts.Repository
+	if repository == "" {
+		repository = DefaultKubectlImageRepository
+	}
+
+	lookup := func(minor uint) (string, bool) {
+		key := fmt.Sprintf("%d.%d", v.Major(), minor)
+		if image := opts.Images[key]; image != "" {
+			return image, true
+		}
+		if tag := DefaultKubectlImageTags[key]; tag != "" {
+			return repository + ":" + tag, true
+		}
+		return "", false
+	}
+
+	candidates := []uint{v.Minor(), v.Minor() + 1}
+	if v.Minor() > 0 {
+		candidates = append(candidates, v.Minor()-1)
+	}
+	for _, minor := range candidates {
+		if image, ok := lookup(minor); ok {
+			return image, nil
+		}
+	}
+	if opts.Fallback != "" {
+		return opts.Fallback, nil
+	}
+	return "", fmt.Errorf("no kubectl image known for server version %d.%d (%s)", v.Major(), v.Minor(), serverVersion)
+}
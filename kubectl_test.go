@@ -0,0 +1,50 @@
+package k8sutils
+
+import "testing"
+
+func TestKubectlImageFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		opts    KubectlImageOptions
+		want    string
+		wantErr bool
+	}{
+		{name: "eks vendor build", version: "v1.29.10-eks-7f9249a", want: "alpine/k8s:1.29.2"},
+		{name: "gke vendor build", version: "v1.28.3-gke.1286000", want: "alpine/k8s:1.28.15"},
+		{name: "upstream build", version: "v1.27.4", want: "alpine/k8s:1.27.16"},
+		{
+			name:    "caller mapping overrides defaults",
+			version: "v1.29.10-eks-7f9249a",
+			opts:    KubectlImageOptions{Images: map[string]string{"1.29": "registry.local/kubectl:1.29.10"}},
+			want:    "registry.local/kubectl:1.29.10",
+		},
+		{
+			name:    "repository override",
+			version: "v1.29.1",
+			opts:    KubectlImageOptions{Repository: "mirror.local/alpine/k8s"},
+			want:    "mirror.local/alpine/k8s:1.29.2",
+		},
+		{name: "newer minor uses the closest older image", version: "v1.32.1", want: "alpine/k8s:1.31.0"},
+		{name: "older minor uses the closest newer image", version: "v1.24.17", want: "alpine/k8s:1.25.16"},
+		{name: "unknown minor without fallback", version: "v1.40.0", wantErr: true},
+		{
+			name:    "unknown minor with fallback",
+			version: "v1.40.0",
+			opts:    KubectlImageOptions{Fallback: "alpine/k8s:latest"},
+			want:    "alpine/k8s:latest",
+		},
+		{name: "unparsable version", version: "unknown", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := kubectlImageFor(tt.version, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("kubectlImageFor(%q) error = %v, wantErr %v", tt.version, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("kubectlImageFor(%q) = %q, want %q", tt.version, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package k8sutils
+
+import (
+	"context"
+	"log/slog"
+
+	"k8s.io/klog/v2"
+)
+
+// Logger is the logging abstraction used by the library, so applications control format and verbosity.
+type Logger interface {
+	Debug(msg string, keysAndValues ...any)
+	Info(msg string, keysAndValues ...any)
+	Error(err error, msg string, keysAndValues ...any)
+}
+
+var defaultLogger Logger = NewKlogLogger(4)
+
+// SetLogger replaces the package-wide default logger. Clientsets created afterwards use it.
+func SetLogger(l Logger) {
+	if l == nil {
+		l = NopLogger()
+	}
+	defaultLogger = l
+}
+
+type klogLogger struct {
+	debugLevel klog.Level
+}
+
+// NewKlogLogger returns a Logger backed by klog, Debug messages are logged at debugLevel verbosity.
+func NewKlogLogger(debugLevel int) Logger {
+	return &klogLogger{debugLevel: klog.Level(debugLevel)}
+}
+
+func (l *klogLogger) Debug(msg string, keysAndValues ...any) {
+	klog.V(l.debugLevel).InfoSDepth(1, msg, keysAndValues...)
+}
+
+func (l *klogLogger) Info(msg string, keysAndValues ...any) {
+	klog.InfoSDepth(1, msg, keysAndValues...)
+}
+
+func (l *klogLogger) Error(err error, msg string, keysAndValues ...any) {
+	klog.ErrorSDepth(1, err, msg, keysAndValues...)
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// NewSlogLogger returns a Logger backed by log/slog, a nil l uses slog.Default().
+func NewSlogLogger(l *slog.Logger) Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return &slogLogger{l: l}
+}
+
+func (l *slogLogger) Debug(msg string, keysAndValues ...any) {
+	l.l.Log(context.Background(), slog.LevelDebug, msg, keysAndValues...)
+}
+
+func (l *slogLogger) Info(msg string, keysAndValues ...any) {
+	l.l.Log(context.Background(), slog.LevelInfo, msg, keysAndValues...)
+}
+
+func (l *slogLogger) Error(err error, msg string, keysAndValues ...any) {
+	l.l.Log(context.Background(), slog.LevelError, msg, append([]any{"err", err}, keysAndValues...)...)
+}
+
+type nopLogger struct{}
+
+// NopLogger returns a Logger that discards everything.
+func NopLogger() Logger {
+	return nopLogger{}
+}
+
+func (nopLogger) Debug(string, ...any)        {}
+func (nopLogger) Info(string, ...any)         {}
+func (nopLogger) Error(error, string, ...any) {}
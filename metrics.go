@@ -0,0 +1,210 @@
+package k8sutils
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RequestMetrics receives one observation per API request made by a client built with WithRequestMetrics.
+// Implementations must be safe for concurrent use; adapt it to Prometheus or any other registry.
+type RequestMetrics interface {
+	Observe(verb, resource string, statusCode int, latency time.Duration, err error)
+}
+
+// NameCollisionMetrics receives one observation per name collision hit while creating an object with a
+// generated name, e.g. by CreateConfigMapWithGeneratedName. Set it with Clientset.SetNameCollisionMetrics.
+type NameCollisionMetrics interface {
+	ObserveNameCollision(resource string)
+}
+
+// WithRequestMetrics records the latency and outcome of every request per (verb, resource).
+func WithRequestMetrics(m RequestMetrics) ClientOption {
+	return WithTransportWrapper(func(rt http.RoundTripper) http.RoundTripper {
+		return &metricsRoundTripper{next: rt, metrics: m}
+	})
+}
+
+type metricsRoundTripper struct {
+	next    http.RoundTripper
+	metrics RequestMetrics
+}
+
+func (t *metricsRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	code := 0
+	if resp != nil {
+		code = resp.StatusCode
+	}
+	verb, resource := requestVerbResource(req)
+	// RoundTrip returns once headers arrive, so for watches this is the time to establish them
+	t.metrics.Observe(verb, resource, code, time.Since(start), err)
+	return resp, err
+}
+
+// namespaceSubresources are the subresources of the Namespace object, mirroring the API server's RequestInfoFactory.
+var namespaceSubresources = map[string]bool{"status": true, "finalize": true}
+
+// requestVerbResource maps a request to a Kubernetes verb and resource, e.g. ("list", "pods")
+// or ("get", "pods/log"), following the /api/v1/... and /apis/group/version/... layouts.
+func requestVerbResource(req *http.Request) (verb, resource string) {
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		parts = parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		parts = parts[3:]
+	default:
+		return strings.ToLower(req.Method), "nonresource"
+	}
+	// namespaces/<ns>/<resource>/... is a namespaced resource, except for the namespace's own
+	// subresources (namespaces/<ns>/status, namespaces/<ns>/finalize) which keep the namespaces prefix
+	if len(parts) >= 3 && parts[0] == "namespaces" && !namespaceSubresources[parts[2]] {
+		parts = parts[2:]
+	}
+	if len(parts) == 0 {
+		return strings.ToLower(req.Method), "discovery"
+	}
+
+	resource = parts[0]
+	hasName := len(parts) >= 2
+	if len(parts) >= 3 {
+		resource += "/" + parts[2]
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		switch {
+		case req.URL.Query().Get("watch") == "true":
+			verb = "watch"
+		case hasName:
+			verb = "get"
+		default:
+			verb = "list"
+		}
+	case http.MethodPost:
+		verb = "create"
+	case http.MethodPut:
+		verb = "update"
+	case http.MethodPatch:
+		verb = "patch"
+	case http.MethodDelete:
+		verb = "delete"
+		if !hasName {
+			verb = "deletecollection"
+		}
+	default:
+		verb = strings.ToLower(req.Method)
+	}
+	return verb, resource
+}
+
+// RequestStats is an in-memory RequestMetrics that keeps latency histograms and error counts
+// per (verb, resource). It is also a NameCollisionMetrics counting collisions per resource.
+type RequestStats struct {
+	mu         sync.Mutex
+	buckets    []time.Duration
+	series     map[string]*RequestSeries
+	collisions map[string]int
+}
+
+type RequestSeries struct {
+	Verb     string
+	Resource string
+	Count    int
+	Errors   int
+	Total    time.Duration
+	// Buckets[i] counts requests that took at most Bounds[i], the last entry counts the rest.
+	Bounds  []time.Duration
+	Buckets []int
+}
+
+func (s RequestSeries) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// DefaultLatencyBuckets are the histogram bounds used when NewRequestStats gets none.
+var DefaultLatencyBuckets = []time.Duration{
+	5 * time.Millisecond, 25 * time.Millisecond, 100 * time.Millisecond,
+	250 * time.Millisecond, time.Second, 5 * time.Second,
+}
+
+func NewRequestStats(buckets ...time.Duration) *RequestStats {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]time.Duration(nil), buckets...)
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+	return &RequestStats{buckets: buckets, series: make(map[string]*RequestSeries), collisions: make(map[string]int)}
+}
+
+// Observe counts requests failing at the transport level or with a 5xx or 429 status as errors.
+func (s *RequestStats) Observe(verb, resource string, statusCode int, latency time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := verb + " " + resource
+	series, ok := s.series[key]
+	if !ok {
+		series = &RequestSeries{
+			Verb:     verb,
+			Resource: resource,
+			Bounds:   s.buckets,
+			Buckets:  make([]int, len(s.buckets)+1),
+		}
+		s.series[key] = series
+	}
+	series.Count++
+	series.Total += latency
+	if err != nil || statusCode >= 500 || statusCode == http.StatusTooManyRequests {
+		series.Errors++
+	}
+	i := sort.Search(len(s.buckets), func(i int) bool { return latency <= s.buckets[i] })
+	series.Buckets[i]++
+}
+
+func (s *RequestStats) ObserveNameCollision(resource string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collisions[resource]++
+}
+
+// NameCollisions returns a copy of the name collision counts per resource.
+func (s *RequestStats) NameCollisions() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make(map[string]int, len(s.collisions))
+	for resource, n := range s.collisions {
+		out[resource] = n
+	}
+	return out
+}
+
+// Snapshot returns a copy of all series sorted by resource and verb.
+func (s *RequestStats) Snapshot() []RequestSeries {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]RequestSeries, 0, len(s.series))
+	for _, series := range s.series {
+		cp := *series
+		cp.Bounds = append([]time.Duration(nil), series.Bounds...)
+		cp.Buckets = append([]int(nil), series.Buckets...)
+		out = append(out, cp)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Resource != out[j].Resource {
+			return out[i].Resource < out[j].Resource
+		}
+		return out[i].Verb < out[j].Verb
+	})
+	return out
+}
@@ -0,0 +1,85 @@
+package k8sutils
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRequestVerbResource(t *testing.T) {
+	tests := []struct {
+		method       string
+		url          string
+		wantVerb     string
+		wantResource string
+	}{
+		{http.MethodGet, "/api/v1/namespaces/ns/pods", "list", "pods"},
+		{http.MethodGet, "/api/v1/pods", "list", "pods"},
+		{http.MethodGet, "/api/v1/namespaces/ns/pods/p", "get", "pods"},
+		{http.MethodGet, "/api/v1/namespaces/ns/pods/p/log", "get", "pods/log"},
+		{http.MethodGet, "/apis/batch/v1/namespaces/ns/jobs?watch=true", "watch", "jobs"},
+		{http.MethodPost, "/api/v1/namespaces/ns/serviceaccounts/sa/token", "create", "serviceaccounts/token"},
+		{http.MethodPatch, "/api/v1/nodes/n1", "patch", "nodes"},
+		{http.MethodDelete, "/api/v1/namespaces/ns/pods", "deletecollection", "pods"},
+		{http.MethodGet, "/api/v1/namespaces", "list", "namespaces"},
+		{http.MethodGet, "/api/v1/namespaces/ns", "get", "namespaces"},
+		{http.MethodDelete, "/api/v1/namespaces/ns", "delete", "namespaces"},
+		{http.MethodPut, "/api/v1/namespaces/ns/finalize", "update", "namespaces/finalize"},
+		{http.MethodPut, "/api/v1/namespaces/ns/status", "update", "namespaces/status"},
+		{http.MethodGet, "/api/v1", "get", "discovery"},
+		{http.MethodGet, "/version", "get", "nonresource"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, "https://apiserver"+tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			verb, resource := requestVerbResource(req)
+			if verb != tt.wantVerb || resource != tt.wantResource {
+				t.Errorf("requestVerbResource() = (%q, %q), want (%q, %q)", verb, resource, tt.wantVerb, tt.wantResource)
+			}
+		})
+	}
+}
+
+func TestRequestStatsSnapshotIsCopy(t *testing.T) {
+	stats := NewRequestStats(10*time.Millisecond, time.Second)
+	stats.Observe("get", "pods", http.StatusOK, 5*time.Millisecond, nil)
+	stats.Observe("get", "pods", http.StatusTooManyRequests, 2*time.Second, nil)
+
+	snap := stats.Snapshot()
+	if len(snap) != 1 {
+		t.Fatalf("Snapshot() returned %d series, want 1", len(snap))
+	}
+	if got := snap[0]; got.Count != 2 || got.Errors != 1 || got.Buckets[0] != 1 || got.Buckets[2] != 1 {
+		t.Errorf("Snapshot() = %+v, want Count=2 Errors=1 Buckets=[1 0 1]", got)
+	}
+
+	snap[0].Bounds[0] = time.Hour
+	snap[0].Buckets[0] = 100
+	again := stats.Snapshot()[0]
+	if again.Bounds[0] != 10*time.Millisecond || again.Buckets[0] != 1 {
+		t.Errorf("mutating a snapshot changed the stats: %+v", again)
+	}
+}
+
+func TestRequestStatsNameCollisions(t *testing.T) {
+	stats := NewRequestStats()
+	kc := &Clientset{}
+	kc.SetNameCollisionMetrics(stats)
+	kc.observeNameCollision("configmaps")
+	kc.observeNameCollision("configmaps")
+
+	got := stats.NameCollisions()
+	if got["configmaps"] != 2 {
+		t.Errorf("NameCollisions() = %v, want configmaps=2", got)
+	}
+	got["configmaps"] = 100
+	if stats.NameCollisions()["configmaps"] != 2 {
+		t.Error("NameCollisions() shares its map with the stats")
+	}
+
+	// no metrics set is a no-op
+	(&Clientset{}).observeNameCollision("configmaps")
+}
@@ -0,0 +1,29 @@
+package k8sutils
+
+import (
+	"context"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func (kc *Clientset) CreateNetworkPolicy(ctx context.Context, np *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	return kc.clientset.NetworkingV1().NetworkPolicies(np.Namespace).Create(ctx, np, metav1.CreateOptions{})
+}
+
+func (kc *Clientset) GetNetworkPolicy(ctx context.Context, namespace, name string) (*networkingv1.NetworkPolicy, error) {
+	return kc.clientset.NetworkingV1().NetworkPolicies(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (kc *Clientset) DeleteNetworkPolicy(ctx context.Context, namespace, name string) error {
+	return kc.clientset.NetworkingV1().NetworkPolicies(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+func (kc *Clientset) ListNetworkPolicy(ctx context.Context, namespace string, selector labels.Selector) (*networkingv1.NetworkPolicyList, error) {
+	opts := metav1.ListOptions{}
+	if selector != nil {
+		opts.LabelSelector = selector.String()
+	}
+	return kc.clientset.NetworkingV1().NetworkPolicies(namespace).List(ctx, opts)
+}
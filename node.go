@@ -0,0 +1,151 @@
+package k8sutils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+func (kc *Clientset) GetNode(ctx context.Context, name string) (*corev1.Node, error) {
+	return kc.clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+}
+
+func (kc *Clientset) ListNode(ctx context.Context, opts metav1.ListOptions) (*corev1.NodeList, error) {
+	return kc.clientset.CoreV1().Nodes().List(ctx, opts)
+}
+
+// LabelNode adds or overwrites the given labels on the node.
+func (kc *Clientset) LabelNode(ctx context.Context, name string, labels map[string]string) (*corev1.Node, error) {
+	return kc.patchNodeMetadata(ctx, name, "labels", toPatchValues(labels))
+}
+
+// UnlabelNode removes the given label keys from the node, missing keys are ignored.
+func (kc *Clientset) UnlabelNode(ctx context.Context, name string, keys ...string) (*corev1.Node, error) {
+	return kc.patchNodeMetadata(ctx, name, "labels", toPatchRemovals(keys))
+}
+
+// AnnotateNode adds or overwrites the given annotations on the node.
+func (kc *Clientset) AnnotateNode(ctx context.Context, name string, annotations map[string]string) (*corev1.Node, error) {
+	return kc.patchNodeMetadata(ctx, name, "annotations", toPatchValues(annotations))
+}
+
+// UnannotateNode removes the given annotation keys from the node, missing keys are ignored.
+func (kc *Clientset) UnannotateNode(ctx context.Context, name string, keys ...string) (*corev1.Node, error) {
+	return kc.patchNodeMetadata(ctx, name, "annotations", toPatchRemovals(keys))
+}
+
+// TaintNode adds the taint to the node, replacing an existing taint with the same key and effect.
+func (kc *Clientset) TaintNode(ctx context.Context, name string, taint corev1.Taint) (*corev1.Node, error) {
+	return kc.updateNodeTaints(ctx, name, func(taints []corev1.Taint) []corev1.Taint {
+		out := make([]corev1.Taint, 0, len(taints)+1)
+		for _, t := range taints {
+			if t.Key == taint.Key && t.Effect == taint.Effect {
+				continue
+			}
+			out = append(out, t)
+		}
+		return append(out, taint)
+	})
+}
+
+// UntaintNode removes taints with the given key from the node. An empty effect removes all effects of the key.
+func (kc *Clientset) UntaintNode(ctx context.Context, name, key string, effect corev1.TaintEffect) (*corev1.Node, error) {
+	return kc.updateNodeTaints(ctx, name, func(taints []corev1.Taint) []corev1.Taint {
+		out := make([]corev1.Taint, 0, len(taints))
+		for _, t := range taints {
+			if t.Key == key && (effect == "" || t.Effect == effect) {
+				continue
+			}
+			out = append(out, t)
+		}
+		return out
+	})
+}
+
+// CheckNodeReady returns nil when the node is Ready and reports no disk, memory or PID pressure.
+// Otherwise the error wraps ErrNodeNotReady and lists the failing conditions, so callers targeting
+// a specific node can fail fast instead of leaving pods Pending.
+func (kc *Clientset) CheckNodeReady(ctx context.Context, name string) error {
+	node, err := kc.GetNode(ctx, name)
+	if err != nil {
+		return fmt.Errorf("error getting node %s: %w", name, err)
+	}
+	if problems := nodeProblems(node); len(problems) > 0 {
+		return fmt.Errorf("%w: node %s: %s", ErrNodeNotReady, name, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+func nodeProblems(node *corev1.Node) []string {
+	var problems []string
+	ready := false
+	for _, c := range node.Status.Conditions {
+		switch c.Type {
+		case corev1.NodeReady:
+			ready = c.Status == corev1.ConditionTrue
+		case corev1.NodeDiskPressure, corev1.NodeMemoryPressure, corev1.NodePIDPressure:
+			if c.Status == corev1.ConditionTrue {
+				problems = append(problems, string(c.Type))
+			}
+		}
+	}
+	if !ready {
+		problems = append([]string{"NotReady"}, problems...)
+	}
+	return problems
+}
+
+func (kc *Clientset) patchNodeMetadata(ctx context.Context, name, field string, values map[string]*string) (*corev1.Node, error) {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{field: values},
+	})
+	if err != nil {
+		return nil, err
+	}
+	node, err := kc.clientset.CoreV1().Nodes().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error patching %s of node %s: %w", field, name, err)
+	}
+	return node, nil
+}
+
+func (kc *Clientset) updateNodeTaints(ctx context.Context, name string, mutate func([]corev1.Taint) []corev1.Taint) (*corev1.Node, error) {
+	var updated *corev1.Node
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := kc.GetNode(ctx, name)
+		if err != nil {
+			return err
+		}
+		node.Spec.Taints = mutate(node.Spec.Taints)
+		updated, err = kc.clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error updating taints of node %s: %w", name, err)
+	}
+	return updated, nil
+}
+
+func toPatchValues(m map[string]string) map[string]*string {
+	out := make(map[string]*string, len(m))
+	for k, v := range m {
+		v := v
+		out[k] = &v
+	}
+	return out
+}
+
+// toPatchRemovals builds a merge patch map where nil values delete the keys.
+func toPatchRemovals(keys []string) map[string]*string {
+	out := make(map[string]*string, len(keys))
+	for _, k := range keys {
+		out[k] = nil
+	}
+	return out
+}
@@ -0,0 +1,232 @@
+package k8sutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/linlanniao/k8sutils/waitutil"
+)
+
+// legacyJobNameLabel is set on job pods by every Kubernetes version, unlike batchv1.JobNameLabel.
+const legacyJobNameLabel = "job-name"
+
+func (kc *Clientset) GetPod(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+	return kc.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (kc *Clientset) ListPod(ctx context.Context, namespace string, selector labels.Selector) (*corev1.PodList, error) {
+	opts := metav1.ListOptions{}
+	if selector != nil {
+		opts.LabelSelector = selector.String()
+	}
+	return kc.clientset.CoreV1().Pods(namespace).List(ctx, opts)
+}
+
+// GetPodsByFieldSelector lists pods matching a field selector, e.g. spec.nodeName or status.phase.
+func (kc *Clientset) GetPodsByFieldSelector(ctx context.Context, namespace string, selector fields.Selector) ([]corev1.Pod, error) {
+	opts := metav1.ListOptions{}
+	if selector != nil {
+		opts.FieldSelector = selector.String()
+	}
+	podList, err := kc.clientset.CoreV1().Pods(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods with fields %q: %w", opts.FieldSelector, err)
+	}
+	return podList.Items, nil
+}
+
+// GetPodsByOwnerUID lists the pods in namespace that have an owner reference to uid.
+// A label selector narrows the list server-side before filtering.
+func (kc *Clientset) GetPodsByOwnerUID(ctx context.Context, namespace string, uid types.UID, selector labels.Selector) ([]corev1.Pod, error) {
+	podList, err := kc.ListPod(ctx, namespace, selector)
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods owned by %s: %w", uid, err)
+	}
+	return filterPodsByOwner(podList.Items, uid), nil
+}
+
+func filterPodsByOwner(pods []corev1.Pod, uid types.UID) []corev1.Pod {
+	out := make([]corev1.Pod, 0, len(pods))
+	for i := range pods {
+		if isOwnedBy(&pods[i], uid) {
+			out = append(out, pods[i])
+		}
+	}
+	return out
+}
+
+func isOwnedBy(pod *corev1.Pod, uid types.UID) bool {
+	for _, ref := range pod.OwnerReferences {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPodsFromJob returns the pods created for the job. Pods are matched by the job's UID so pods left over
+// from a deleted job with the same name are ignored. If the job does not exist its NotFound error is returned.
+// Right after the job is created there may be no pods yet, in which case an empty list is returned
+// together with ErrNoPodsYet.
+func (kc *Clientset) GetPodsFromJob(ctx context.Context, namespace, jobName string) ([]corev1.Pod, error) {
+	job, err := kc.GetJob(ctx, namespace, jobName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting job %s/%s: %w", namespace, jobName, err)
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{legacyJobNameLabel: jobName})
+	pods, err := kc.GetPodsByOwnerUID(ctx, namespace, job.UID, selector)
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods of job %s/%s: %w", namespace, jobName, err)
+	}
+
+	if len(pods) == 0 {
+		return []corev1.Pod{}, ErrNoPodsYet
+	}
+	return pods, nil
+}
+
+// WaitForJobPods waits until the job has at least minCount pods or the timeout expires.
+// A zero timeout waits until ctx is done.
+func (kc *Clientset) WaitForJobPods(ctx context.Context, namespace, jobName string, minCount int, timeout time.Duration) ([]corev1.Pod, error) {
+	if minCount < 1 {
+		minCount = 1
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	job, err := kc.GetJob(ctx, namespace, jobName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting job %s/%s: %w", namespace, jobName, err)
+	}
+
+	// the label narrows the watch, the owner check drops pods of an earlier job with the same name
+	selector := labels.SelectorFromSet(labels.Set{legacyJobNameLabel: jobName})
+	pods, err := waitutil.WaitForPodCountMatching(ctx, kc.clientset, namespace, selector, minCount, func(pod *corev1.Pod) bool {
+		return isOwnedBy(pod, job.UID)
+	})
+	if err != nil {
+		return pods, fmt.Errorf("error waiting for pods of job %s/%s: %w", namespace, jobName, err)
+	}
+	return pods, nil
+}
+
+// DeletePod deletes the pod. A nil opts uses background propagation and the pod's own grace period;
+// pass Foreground or Orphan, GracePeriodSeconds or a UID precondition to change that.
+func (kc *Clientset) DeletePod(ctx context.Context, namespace, name string, opts *metav1.DeleteOptions) error {
+	if opts == nil {
+		policy := metav1.DeletePropagationBackground
+		opts = &metav1.DeleteOptions{PropagationPolicy: &policy}
+	}
+	return kc.clientset.CoreV1().Pods(namespace).Delete(ctx, name, *opts)
+}
+
+// listPodsOnNode requires a selector so the destructive callers never act on every pod of a node
+// by accident; labels.Everything() has to be passed explicitly.
+func (kc *Clientset) listPodsOnNode(ctx context.Context, nodeName string, selector labels.Selector) ([]corev1.Pod, error) {
+	if selector == nil {
+		return nil, fmt.Errorf("error listing pods on node %s: a label selector is required, use labels.Everything() to select all pods", nodeName)
+	}
+	opts := metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+		LabelSelector: selector.String(),
+	}
+	podList, err := kc.clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods on node %s: %w", nodeName, err)
+	}
+	return podList.Items, nil
+}
+
+// DeletePodsOnNode deletes the pods scheduled on nodeName that match selector, in all namespaces.
+// selector is required. Mirror pods and DaemonSet pods are skipped since the kubelet or the DaemonSet
+// controller would recreate them right away. A nil gracePeriodSeconds keeps each pod's own termination
+// grace period. It returns the deleted pods; failures do not stop the remaining deletions and are returned joined.
+func (kc *Clientset) DeletePodsOnNode(ctx context.Context, nodeName string, selector labels.Selector, gracePeriodSeconds *int64) ([]types.NamespacedName, error) {
+	pods, err := kc.listPodsOnNode(ctx, nodeName, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := metav1.DeletePropagationBackground
+	var (
+		deleted []types.NamespacedName
+		errs    []error
+	)
+	for _, pod := range pods {
+		if isMirrorOrDaemonSetPod(&pod) {
+			continue
+		}
+		opts := &metav1.DeleteOptions{
+			GracePeriodSeconds: gracePeriodSeconds,
+			PropagationPolicy:  &policy,
+			Preconditions:      metav1.NewUIDPreconditions(string(pod.UID)),
+		}
+		if err := kc.DeletePod(ctx, pod.Namespace, pod.Name, opts); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("error deleting pod %s/%s: %w", pod.Namespace, pod.Name, err))
+			continue
+		}
+		deleted = append(deleted, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name})
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// EvictPodsOnNode is the safer variant of DeletePodsOnNode: it uses the eviction API so PodDisruptionBudgets
+// are honored, and additionally skips pods that already finished. selector is required.
+func (kc *Clientset) EvictPodsOnNode(ctx context.Context, nodeName string, selector labels.Selector, gracePeriodSeconds *int64) ([]types.NamespacedName, error) {
+	pods, err := kc.listPodsOnNode(ctx, nodeName, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		evicted []types.NamespacedName
+		errs    []error
+	)
+	for _, pod := range pods {
+		if skipEviction(&pod) {
+			continue
+		}
+		eviction := &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name},
+			DeleteOptions: &metav1.DeleteOptions{
+				GracePeriodSeconds: gracePeriodSeconds,
+				Preconditions:      metav1.NewUIDPreconditions(string(pod.UID)),
+			},
+		}
+		if err := kc.clientset.CoreV1().Pods(pod.Namespace).EvictV1(ctx, eviction); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("error evicting pod %s/%s: %w", pod.Namespace, pod.Name, err))
+			continue
+		}
+		evicted = append(evicted, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name})
+	}
+	return evicted, errors.Join(errs...)
+}
+
+func skipEviction(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return true
+	}
+	return isMirrorOrDaemonSetPod(pod)
+}
+
+func isMirrorOrDaemonSetPod(pod *corev1.Pod) bool {
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return true
+	}
+	owner := metav1.GetControllerOf(pod)
+	return owner != nil && owner.Kind == "DaemonSet"
+}
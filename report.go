@@ -0,0 +1,149 @@
+package k8sutils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type ReportItem struct {
+	Kind              string        `json:"kind"`
+	Name              string        `json:"name"`
+	CreationTimestamp time.Time     `json:"creationTimestamp"`
+	Age               time.Duration `json:"age"`
+	// Size is the serialized size of the object in bytes, for ConfigMaps and Secrets
+	// it is the size of their data.
+	Size int `json:"size"`
+}
+
+// MarshalJSON encodes Age as a duration string such as "26h3m4s" instead of nanoseconds.
+func (i ReportItem) MarshalJSON() ([]byte, error) {
+	type alias ReportItem
+	return json.Marshal(struct {
+		alias
+		Age string `json:"age"`
+	}{alias(i), i.Age.Round(time.Second).String()})
+}
+
+func (i *ReportItem) UnmarshalJSON(data []byte) error {
+	type alias ReportItem
+	aux := struct {
+		*alias
+		Age string `json:"age"`
+	}{alias: (*alias)(i)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.Age == "" {
+		i.Age = 0
+		return nil
+	}
+	age, err := time.ParseDuration(aux.Age)
+	if err != nil {
+		return fmt.Errorf("error parsing age %q: %w", aux.Age, err)
+	}
+	i.Age = age
+	return nil
+}
+
+type NamespaceReport struct {
+	Namespace   string       `json:"namespace"`
+	Selector    string       `json:"selector"`
+	GeneratedAt time.Time    `json:"generatedAt"`
+	Items       []ReportItem `json:"items"`
+	TotalSize   int          `json:"totalSize"`
+}
+
+// OlderThan returns the items created more than d before the report was generated,
+// which is what a TTL based garbage collection would delete.
+func (r *NamespaceReport) OlderThan(d time.Duration) []ReportItem {
+	var out []ReportItem
+	for _, item := range r.Items {
+		if item.Age > d {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// NamespaceReport lists the jobs, pods, configmaps and secrets in namespace matching selector,
+// with their ages and sizes. selector must not be empty: the report previews what a cleanup of
+// labeled resources would delete, not the whole namespace.
+func (kc *Clientset) NamespaceReport(ctx context.Context, namespace string, selector labels.Selector) (*NamespaceReport, error) {
+	if selector == nil || selector.Empty() {
+		return nil, fmt.Errorf("error building report for namespace %s: a non-empty label selector is required", namespace)
+	}
+	opts := metav1.ListOptions{LabelSelector: selector.String()}
+	report := &NamespaceReport{
+		Namespace:   namespace,
+		Selector:    selector.String(),
+		GeneratedAt: time.Now(),
+	}
+	add := func(kind string, meta metav1.ObjectMeta, size int) {
+		report.Items = append(report.Items, ReportItem{
+			Kind:              kind,
+			Name:              meta.Name,
+			CreationTimestamp: meta.CreationTimestamp.Time,
+			Age:               report.GeneratedAt.Sub(meta.CreationTimestamp.Time),
+			Size:              size,
+		})
+		report.TotalSize += size
+	}
+
+	jobs, err := kc.clientset.BatchV1().Jobs(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing jobs: %w", err)
+	}
+	for i := range jobs.Items {
+		add("Job", jobs.Items[i].ObjectMeta, objectSize(&jobs.Items[i]))
+	}
+
+	pods, err := kc.clientset.CoreV1().Pods(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods: %w", err)
+	}
+	for i := range pods.Items {
+		add("Pod", pods.Items[i].ObjectMeta, objectSize(&pods.Items[i]))
+	}
+
+	configMaps, err := kc.clientset.CoreV1().ConfigMaps(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing configmaps: %w", err)
+	}
+	for _, cm := range configMaps.Items {
+		size := 0
+		for _, v := range cm.Data {
+			size += len(v)
+		}
+		for _, v := range cm.BinaryData {
+			size += len(v)
+		}
+		add("ConfigMap", cm.ObjectMeta, size)
+	}
+
+	secrets, err := kc.clientset.CoreV1().Secrets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing secrets: %w", err)
+	}
+	for _, s := range secrets.Items {
+		size := 0
+		for _, v := range s.Data {
+			size += len(v)
+		}
+		add("Secret", s.ObjectMeta, size)
+	}
+
+	return report, nil
+}
+
+func objectSize(obj any) int {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
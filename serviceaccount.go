@@ -0,0 +1,31 @@
+package k8sutils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreateServiceAccountToken requests a short-lived token for the service account through the TokenRequest API.
+// A zero ttl lets the API server pick its default; the server may also shorten the requested ttl,
+// so callers should rely on the returned expiration.
+func (kc *Clientset) CreateServiceAccountToken(ctx context.Context, namespace, serviceAccount string, audiences []string, ttl time.Duration) (*authenticationv1.TokenRequestStatus, error) {
+	req := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences: audiences,
+		},
+	}
+	if ttl > 0 {
+		seconds := int64(ttl.Seconds())
+		req.Spec.ExpirationSeconds = &seconds
+	}
+
+	result, err := kc.clientset.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, serviceAccount, req, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error creating token for service account %s/%s: %w", namespace, serviceAccount, err)
+	}
+	return &result.Status, nil
+}
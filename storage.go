@@ -0,0 +1,66 @@
+package k8sutils
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	betaDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+)
+
+func (kc *Clientset) ListStorageClasses(ctx context.Context) (*storagev1.StorageClassList, error) {
+	return kc.clientset.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+}
+
+// GetDefaultStorageClass returns the storage class marked as default. When several are marked,
+// the newest one wins, as the DefaultStorageClass admission plugin does.
+// ErrNoDefaultStorageClass is returned when none is marked.
+func (kc *Clientset) GetDefaultStorageClass(ctx context.Context) (*storagev1.StorageClass, error) {
+	classes, err := kc.ListStorageClasses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing storage classes: %w", err)
+	}
+
+	var found *storagev1.StorageClass
+	for i := range classes.Items {
+		sc := &classes.Items[i]
+		if sc.Annotations[defaultStorageClassAnnotation] != "true" && sc.Annotations[betaDefaultStorageClassAnnotation] != "true" {
+			continue
+		}
+		if found == nil || found.CreationTimestamp.Before(&sc.CreationTimestamp) {
+			found = sc
+		}
+	}
+	if found == nil {
+		return nil, ErrNoDefaultStorageClass
+	}
+	return found, nil
+}
+
+// ListPersistentVolumes lists persistent volumes, keeping only the given phases when any are passed.
+func (kc *Clientset) ListPersistentVolumes(ctx context.Context, phases ...corev1.PersistentVolumePhase) ([]corev1.PersistentVolume, error) {
+	pvList, err := kc.clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing persistent volumes: %w", err)
+	}
+	if len(phases) == 0 {
+		return pvList.Items, nil
+	}
+
+	pvs := make([]corev1.PersistentVolume, 0, len(pvList.Items))
+	for _, pv := range pvList.Items {
+		for _, phase := range phases {
+			if pv.Status.Phase == phase {
+				pvs = append(pvs, pv)
+				break
+			}
+		}
+	}
+	return pvs, nil
+}
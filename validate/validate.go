@@ -0,0 +1,305 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const tagName = "validate"
+
+type Validator interface {
+	Validate() error
+}
+
+type FieldError struct {
+	Field string
+	Rule  string
+	Msg   string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %s: %s", e.Field, e.Msg)
+}
+
+type Errors []error
+
+func (es Errors) Error() string {
+	msgs := make([]string, 0, len(es))
+	for _, e := range es {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (es Errors) Unwrap() []error {
+	return es
+}
+
+// Validate checks the struct tags of v and of every struct reachable from it through fields,
+// pointers, slices, arrays and maps, and calls Validate() on each value implementing Validator,
+// v included. All failures are collected into a single Errors.
+func Validate(v any) error {
+	if v == nil {
+		return errors.New("validate: nil value")
+	}
+
+	w := &walker{visited: make(map[visitKey]bool)}
+	w.walk(reflect.ValueOf(v), "", false)
+
+	if len(w.errs) == 0 {
+		return nil
+	}
+	return w.errs
+}
+
+var validatorType = reflect.TypeOf((*Validator)(nil)).Elem()
+
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type walker struct {
+	errs Errors
+	// visited holds the pointers already walked, so self-referential values terminate
+	visited map[visitKey]bool
+}
+
+// walk validates v, named name in error messages. skipValidate suppresses v's own Validate() call,
+// used for embedded fields whose method is already reachable through the parent.
+func (w *walker) walk(v reflect.Value, name string, skipValidate bool) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		key := visitKey{ptr: v.Pointer(), typ: v.Type()}
+		if w.visited[key] {
+			return
+		}
+		w.visited[key] = true
+		w.walk(v.Elem(), name, skipValidate)
+		return
+	case reflect.Interface:
+		if !v.IsNil() {
+			w.walk(v.Elem(), name, skipValidate)
+		}
+		return
+	case reflect.Struct:
+		w.walkFields(v, name)
+	case reflect.Slice, reflect.Array:
+		if !mayValidate(v.Type().Elem()) {
+			break
+		}
+		for i := 0; i < v.Len(); i++ {
+			w.walk(v.Index(i), fmt.Sprintf("%s[%d]", name, i), false)
+		}
+	case reflect.Map:
+		if !mayValidate(v.Type().Elem()) {
+			break
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, k := range keys {
+			w.walk(v.MapIndex(k), fmt.Sprintf("%s[%v]", name, k), false)
+		}
+	}
+
+	if !skipValidate {
+		w.callValidate(v, name)
+	}
+}
+
+// mayValidate reports whether values of t can hold tags or Validators, so element loops over
+// e.g. []byte or map[string]string are skipped.
+func mayValidate(t reflect.Type) bool {
+	return mayValidateType(t, make(map[reflect.Type]bool))
+}
+
+func mayValidateType(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		// a recursive container type without structs, e.g. type L []L
+		return false
+	}
+	seen[t] = true
+	if t.Implements(validatorType) || reflect.PointerTo(t).Implements(validatorType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return mayValidateType(t.Elem(), seen)
+	}
+	return false
+}
+
+func (w *walker) walkFields(rv reflect.Value, prefix string) {
+	rt := rv.Type()
+	// an embedded Validator's method is promoted to the parent (or the parent overrides it and
+	// is responsible for calling it), so it is only called once, through the parent
+	parentValidates := rt.Implements(validatorType) || (rv.CanAddr() && reflect.PointerTo(rt).Implements(validatorType))
+
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		name := sf.Name
+		if prefix != "" {
+			name = prefix + "." + sf.Name
+		}
+
+		if tag, ok := sf.Tag.Lookup(tagName); ok && tag != "-" {
+			for _, r := range parseRules(tag) {
+				if err := r.check(name, fv); err != nil {
+					w.errs = append(w.errs, err)
+				}
+			}
+		}
+
+		w.walk(fv, name, sf.Anonymous && parentValidates)
+	}
+}
+
+func (w *walker) callValidate(v reflect.Value, name string) {
+	var vv Validator
+	if v.CanAddr() {
+		vv, _ = v.Addr().Interface().(Validator)
+	} else if v.CanInterface() {
+		vv, _ = v.Interface().(Validator)
+	}
+	if vv == nil {
+		return
+	}
+	if err := vv.Validate(); err != nil {
+		if name != "" {
+			err = fmt.Errorf("field %s: %w", name, err)
+		}
+		w.errs = append(w.errs, err)
+	}
+}
+
+type rule struct {
+	name  string
+	param string
+}
+
+// parseRules splits a tag like "required,min=1,max=63,pattern=^[a-z,]+$".
+// pattern must be the last rule since its expression may contain commas.
+func parseRules(tag string) []rule {
+	var rules []rule
+	for len(tag) > 0 {
+		if strings.HasPrefix(tag, "pattern=") {
+			rules = append(rules, rule{name: "pattern", param: strings.TrimPrefix(tag, "pattern=")})
+			break
+		}
+		part, rest, _ := strings.Cut(tag, ",")
+		tag = rest
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(part, "=")
+		rules = append(rules, rule{name: name, param: param})
+	}
+	return rules
+}
+
+func (r rule) check(field string, v reflect.Value) error {
+	fail := func(format string, args ...any) error {
+		return &FieldError{Field: field, Rule: r.name, Msg: fmt.Sprintf(format, args...)}
+	}
+
+	switch r.name {
+	case "required":
+		if v.IsZero() {
+			return fail("is required")
+		}
+	case "min", "max":
+		limit, err := strconv.ParseFloat(r.param, 64)
+		if err != nil {
+			return fail("invalid %s parameter %q", r.name, r.param)
+		}
+		for v.Kind() == reflect.Pointer {
+			// unset optional fields are left to the required rule
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+		n, isLen, ok := measure(v)
+		if !ok {
+			return fail("%s is not supported for %s", r.name, v.Kind())
+		}
+		what := "value"
+		if isLen {
+			what = "length"
+		}
+		if r.name == "min" && n < limit {
+			return fail("%s must be >= %s", what, r.param)
+		}
+		if r.name == "max" && n > limit {
+			return fail("%s must be <= %s", what, r.param)
+		}
+	case "pattern":
+		re, err := compile(r.param)
+		if err != nil {
+			return fail("invalid pattern %q: %v", r.param, err)
+		}
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.String {
+			return fail("pattern is not supported for %s", v.Kind())
+		}
+		// empty values are left to the required rule
+		if s := v.String(); s != "" && !re.MatchString(s) {
+			return fail("%q does not match pattern %q", s, r.param)
+		}
+	default:
+		return fail("unknown rule %q", r.name)
+	}
+	return nil
+}
+
+// measure returns the numeric value of v, or its length for strings, slices and maps.
+func measure(v reflect.Value) (n float64, isLen bool, ok bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), false, true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), false, true
+	case reflect.String:
+		return float64(len([]rune(v.String()))), true, true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), true, true
+	}
+	return 0, false, false
+}
+
+var patterns sync.Map // map[string]*regexp.Regexp
+
+func compile(expr string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(expr); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(expr, re)
+	return re, nil
+}
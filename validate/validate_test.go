@@ -0,0 +1,227 @@
+package validate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type inner struct {
+	X int `validate:"min=1"`
+}
+
+func (i inner) Validate() error {
+	if i.X == 5 {
+		return errors.New("five is not allowed")
+	}
+	return nil
+}
+
+type spec struct {
+	Name   string   `validate:"required,max=8,pattern=^[a-z,]+$"`
+	Count  *int     `validate:"min=1,max=10"`
+	Needed *int64   `validate:"required"`
+	Args   []string `validate:"max=2"`
+	Inner  inner
+	Ptr    *inner
+}
+
+func intPtr(i int) *int       { return &i }
+func int64Ptr(i int64) *int64 { return &i }
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   any
+		// wantErrs are substrings expected in the aggregated error, in order; none means valid.
+		wantErrs []string
+	}{
+		{
+			name: "valid",
+			in:   &spec{Name: "a,b", Count: intPtr(3), Needed: int64Ptr(1), Inner: inner{X: 1}},
+		},
+		{
+			name: "nil optional pointer skips min and max",
+			in:   spec{Name: "abc", Needed: int64Ptr(0), Inner: inner{X: 1}},
+		},
+		{
+			name:     "set pointer is checked against min",
+			in:       spec{Name: "abc", Count: intPtr(0), Needed: int64Ptr(0), Inner: inner{X: 1}},
+			wantErrs: []string{"field Count: value must be >= 1"},
+		},
+		{
+			name:     "nil required pointer",
+			in:       spec{Name: "abc", Inner: inner{X: 1}},
+			wantErrs: []string{"field Needed: is required"},
+		},
+		{
+			name:     "pattern containing commas",
+			in:       spec{Name: "a;b", Needed: int64Ptr(0), Inner: inner{X: 1}},
+			wantErrs: []string{`field Name: "a;b" does not match pattern "^[a-z,]+$"`},
+		},
+		{
+			name:     "nested Validate errors",
+			in:       spec{Name: "abc", Needed: int64Ptr(0), Inner: inner{X: 5}, Ptr: &inner{X: 5}},
+			wantErrs: []string{"field Inner: five is not allowed", "field Ptr: five is not allowed"},
+		},
+		{
+			name: "errors are aggregated",
+			in:   spec{Name: "ABCDEFGHIJ", Args: []string{"a", "b", "c"}, Ptr: &inner{}},
+			wantErrs: []string{
+				"field Name: length must be <= 8",
+				"field Name: \"ABCDEFGHIJ\" does not match pattern",
+				"field Needed: is required",
+				"field Args: length must be <= 2",
+				"field Inner.X: value must be >= 1",
+				"field Ptr.X: value must be >= 1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.in)
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+
+			var errs Errors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Validate() = %v, want Errors", err)
+			}
+			if len(errs) != len(tt.wantErrs) {
+				t.Fatalf("Validate() returned %d errors %v, want %d", len(errs), errs, len(tt.wantErrs))
+			}
+			for i, want := range tt.wantErrs {
+				if !strings.Contains(errs[i].Error(), want) {
+					t.Errorf("error %d = %q, want it to contain %q", i, errs[i], want)
+				}
+			}
+		})
+	}
+}
+
+type node struct {
+	Name string `validate:"required"`
+	Next *node
+}
+
+type item struct {
+	N *int `validate:"required"`
+}
+
+type withCollections struct {
+	Items  []item
+	Ptrs   []*item
+	Array  [1]item
+	ByName map[string]item
+	Bytes  []byte
+}
+
+type embedding struct {
+	inner2
+	Name string `validate:"required"`
+}
+
+type inner2 struct {
+	X int
+}
+
+func (i inner2) Validate() error {
+	if i.X == 5 {
+		return errors.New("five")
+	}
+	return nil
+}
+
+type Inner3 struct {
+	X int
+}
+
+func (i Inner3) Validate() error {
+	if i.X == 5 {
+		return errors.New("five")
+	}
+	return nil
+}
+
+type exportedEmbedding struct {
+	Inner3
+}
+
+func TestValidateReferencesAndCollections(t *testing.T) {
+	cyclic := &node{}
+	cyclic.Next = cyclic
+
+	two := &node{Name: "a"}
+	two.Next = &node{Next: two}
+
+	tests := []struct {
+		name     string
+		in       any
+		wantErrs []string
+	}{
+		{name: "self-referential value terminates", in: cyclic, wantErrs: []string{"field Name: is required"}},
+		{name: "cycle through two nodes", in: two, wantErrs: []string{"field Next.Name: is required"}},
+		{
+			name: "slice, array and map elements are validated",
+			in: withCollections{
+				Items:  []item{{N: intPtr(1)}, {}},
+				Ptrs:   []*item{nil, {}},
+				ByName: map[string]item{"b": {}, "a": {N: intPtr(1)}},
+				Bytes:  []byte("abc"),
+			},
+			wantErrs: []string{
+				"field Items[1].N: is required",
+				"field Ptrs[1].N: is required",
+				"field Array[0].N: is required",
+				"field ByName[b].N: is required",
+			},
+		},
+		{name: "promoted Validate is reported once", in: exportedEmbedding{Inner3{X: 5}}, wantErrs: []string{"five"}},
+		{name: "unexported embedded Validate is reported once", in: &embedding{inner2: inner2{X: 5}, Name: "a"}, wantErrs: []string{"five"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs Errors
+			if !errors.As(Validate(tt.in), &errs) {
+				t.Fatalf("Validate() returned no Errors, want %v", tt.wantErrs)
+			}
+			if len(errs) != len(tt.wantErrs) {
+				t.Fatalf("Validate() returned %d errors %v, want %d", len(errs), errs, len(tt.wantErrs))
+			}
+			for i, want := range tt.wantErrs {
+				if errs[i].Error() != want {
+					t.Errorf("error %d = %q, want %q", i, errs[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateFieldError(t *testing.T) {
+	err := Validate(spec{Name: "abc", Inner: inner{X: 1}})
+
+	var fe *FieldError
+	if !errors.As(err, &fe) {
+		t.Fatalf("Validate() = %v, want a *FieldError inside", err)
+	}
+	if fe.Field != "Needed" || fe.Rule != "required" {
+		t.Errorf("FieldError = %+v, want Field=Needed Rule=required", fe)
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	got := parseRules("required, min=1,pattern=^a,b{1,2}$")
+	want := []rule{{name: "required"}, {name: "min", param: "1"}, {name: "pattern", param: "^a,b{1,2}$"}}
+	if len(got) != len(want) {
+		t.Fatalf("parseRules() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("rule %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package waitutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+var (
+	// ErrDeleted is returned when the awaited object is deleted before reaching the expected state.
+	ErrDeleted = errors.New("object was deleted")
+
+	// ErrPodTerminated is returned when a pod finished without reaching the expected state.
+	ErrPodTerminated = errors.New("pod terminated")
+)
+
+// PodListWatch watches the single pod namespace/name.
+func PodListWatch(ctx context.Context, client kubernetes.Interface, namespace, name string) cache.ListerWatcher {
+	return podListWatch(ctx, client, namespace, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String(),
+	})
+}
+
+// JobListWatch watches the single job namespace/name.
+func JobListWatch(ctx context.Context, client kubernetes.Interface, namespace, name string) cache.ListerWatcher {
+	selector := fields.OneTermEqualSelector("metadata.name", name).String()
+	return &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			opts.FieldSelector = selector
+			return client.BatchV1().Jobs(namespace).List(ctx, opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			opts.FieldSelector = selector
+			return client.BatchV1().Jobs(namespace).Watch(ctx, opts)
+		},
+	}
+}
+
+func podListWatch(ctx context.Context, client kubernetes.Interface, namespace string, base metav1.ListOptions) cache.ListerWatcher {
+	return &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			opts.FieldSelector, opts.LabelSelector = base.FieldSelector, base.LabelSelector
+			return client.CoreV1().Pods(namespace).List(ctx, opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			opts.FieldSelector, opts.LabelSelector = base.FieldSelector, base.LabelSelector
+			return client.CoreV1().Pods(namespace).Watch(ctx, opts)
+		},
+	}
+}
+
+// until runs the condition against the watch events of lw until it returns true, an error, or ctx is done.
+func until(ctx context.Context, lw cache.ListerWatcher, objType runtime.Object, precondition watchtools.PreconditionFunc, cond watchtools.ConditionFunc) (*watch.Event, error) {
+	ev, err := watchtools.UntilWithSync(ctx, lw, objType, precondition, cond)
+	if err != nil && ctx.Err() != nil {
+		return ev, ctx.Err()
+	}
+	return ev, err
+}
+
+// WaitForPodPhase waits until the pod is in one of phases. It fails fast with ErrPodTerminated when the pod
+// reaches a terminal phase that was not asked for.
+func WaitForPodPhase(ctx context.Context, client kubernetes.Interface, namespace, name string, phases ...corev1.PodPhase) (*corev1.Pod, error) {
+	var pod *corev1.Pod
+	_, err := until(ctx, PodListWatch(ctx, client, namespace, name), &corev1.Pod{}, nil, func(ev watch.Event) (bool, error) {
+		if ev.Type == watch.Deleted {
+			return false, ErrDeleted
+		}
+		p, ok := ev.Object.(*corev1.Pod)
+		if !ok {
+			return false, nil
+		}
+		pod = p
+		for _, phase := range phases {
+			if p.Status.Phase == phase {
+				return true, nil
+			}
+		}
+		if isPodTerminated(p) {
+			return false, fmt.Errorf("%w: phase %s", ErrPodTerminated, p.Status.Phase)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return pod, fmt.Errorf("error waiting for pod %s/%s phase %v: %w", namespace, name, phases, err)
+	}
+	return pod, nil
+}
+
+// WaitForPodReady waits until the pod reports the Ready condition.
+func WaitForPodReady(ctx context.Context, client kubernetes.Interface, namespace, name string) (*corev1.Pod, error) {
+	var pod *corev1.Pod
+	_, err := until(ctx, PodListWatch(ctx, client, namespace, name), &corev1.Pod{}, nil, func(ev watch.Event) (bool, error) {
+		if ev.Type == watch.Deleted {
+			return false, ErrDeleted
+		}
+		p, ok := ev.Object.(*corev1.Pod)
+		if !ok {
+			return false, nil
+		}
+		pod = p
+		for _, c := range p.Status.Conditions {
+			if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+				return true, nil
+			}
+		}
+		if isPodTerminated(p) {
+			return false, fmt.Errorf("%w: phase %s", ErrPodTerminated, p.Status.Phase)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return pod, fmt.Errorf("error waiting for pod %s/%s to be ready: %w", namespace, name, err)
+	}
+	return pod, nil
+}
+
+// WaitForPodCount waits until at least minCount pods match selector in namespace.
+func WaitForPodCount(ctx context.Context, client kubernetes.Interface, namespace string, selector labels.Selector, minCount int) ([]corev1.Pod, error) {
+	return WaitForPodCountMatching(ctx, client, namespace, selector, minCount, nil)
+}
+
+// WaitForPodCountMatching is WaitForPodCount counting only the pods for which match returns true,
+// for checks a label selector cannot express such as owner references. A nil match counts every pod.
+func WaitForPodCountMatching(ctx context.Context, client kubernetes.Interface, namespace string, selector labels.Selector, minCount int, match func(*corev1.Pod) bool) ([]corev1.Pod, error) {
+	if selector == nil {
+		selector = labels.Everything()
+	}
+	lw := podListWatch(ctx, client, namespace, metav1.ListOptions{LabelSelector: selector.String()})
+
+	// keyed by namespace/name, namespace may be empty to watch all namespaces
+	seen := make(map[string]*corev1.Pod)
+	_, err := until(ctx, lw, &corev1.Pod{}, nil, func(ev watch.Event) (bool, error) {
+		p, ok := ev.Object.(*corev1.Pod)
+		if !ok {
+			return false, nil
+		}
+		key := p.Namespace + "/" + p.Name
+		if ev.Type == watch.Deleted || (match != nil && !match(p)) {
+			delete(seen, key)
+		} else {
+			seen[key] = p
+		}
+		return len(seen) >= minCount, nil
+	})
+
+	pods := make([]corev1.Pod, 0, len(seen))
+	for _, p := range seen {
+		pods = append(pods, *p)
+	}
+	if err != nil {
+		return pods, fmt.Errorf("error waiting for %d pods matching %q: %w", minCount, selector.String(), err)
+	}
+	return pods, nil
+}
+
+type JobOutcome string
+
+const (
+	JobSucceeded        JobOutcome = "Succeeded"
+	JobFailed           JobOutcome = "Failed"
+	JobDeadlineExceeded JobOutcome = "DeadlineExceeded"
+)
+
+type JobResult struct {
+	Job     *batchv1.Job
+	Outcome JobOutcome
+	// Reason and Message come from the Job's Complete or Failed condition.
+	Reason  string
+	Message string
+}
+
+// WaitForJobComplete waits until the job has a Complete or Failed condition and reports which one.
+// A failed job is not an error; inspect Outcome.
+func WaitForJobComplete(ctx context.Context, client kubernetes.Interface, namespace, name string) (*JobResult, error) {
+	var result *JobResult
+	_, err := until(ctx, JobListWatch(ctx, client, namespace, name), &batchv1.Job{}, nil, func(ev watch.Event) (bool, error) {
+		if ev.Type == watch.Deleted {
+			return false, ErrDeleted
+		}
+		job, ok := ev.Object.(*batchv1.Job)
+		if !ok {
+			return false, nil
+		}
+		result = JobResultOf(job)
+		return result != nil, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for job %s/%s to complete: %w", namespace, name, err)
+	}
+	return result, nil
+}
+
+// JobResultOf returns the result of a finished job, or nil when it is still running.
+func JobResultOf(job *batchv1.Job) *JobResult {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return &JobResult{Job: job, Outcome: JobSucceeded, Reason: c.Reason, Message: c.Message}
+		case batchv1.JobFailed:
+			outcome := JobFailed
+			if c.Reason == "DeadlineExceeded" {
+				outcome = JobDeadlineExceeded
+			}
+			return &JobResult{Job: job, Outcome: outcome, Reason: c.Reason, Message: c.Message}
+		}
+	}
+	return nil
+}
+
+// WaitForDeletion waits until the object watched by lw is gone. lw is expected to select a single object,
+// e.g. PodListWatch or JobListWatch.
+func WaitForDeletion(ctx context.Context, lw cache.ListerWatcher, objType runtime.Object) error {
+	precondition := func(store cache.Store) (bool, error) {
+		return len(store.List()) == 0, nil
+	}
+	_, err := until(ctx, lw, objType, precondition, func(ev watch.Event) (bool, error) {
+		return ev.Type == watch.Deleted, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for deletion: %w", err)
+	}
+	return nil
+}
+
+func isPodTerminated(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
@@ -0,0 +1,119 @@
+package waitutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func testPod(name string, podLabels map[string]string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: podLabels}}
+}
+
+func TestWaitForJobComplete(t *testing.T) {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "job"},
+		Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{
+			Type:    batchv1.JobFailed,
+			Status:  corev1.ConditionTrue,
+			Reason:  "DeadlineExceeded",
+			Message: "Job was active longer than specified deadline",
+		}}},
+	}
+	client := fake.NewSimpleClientset(job)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := WaitForJobComplete(ctx, client, "default", "job")
+	if err != nil {
+		t.Fatalf("WaitForJobComplete() error = %v", err)
+	}
+	if result.Outcome != JobDeadlineExceeded || result.Reason != "DeadlineExceeded" {
+		t.Errorf("WaitForJobComplete() = %s/%s, want %s/DeadlineExceeded", result.Outcome, result.Reason, JobDeadlineExceeded)
+	}
+}
+
+func TestWaitForDeletion(t *testing.T) {
+	t.Run("object deleted while waiting", func(t *testing.T) {
+		client := fake.NewSimpleClientset(testPod("pod", nil))
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		lw := PodListWatch(ctx, client, "default", "pod")
+		// delete once the watch is established so the event cannot be missed
+		withDelete := &cache.ListWatch{
+			ListFunc: lw.List,
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				w, err := lw.Watch(opts)
+				if err == nil {
+					go func() {
+						if err := client.CoreV1().Pods("default").Delete(ctx, "pod", metav1.DeleteOptions{}); err != nil {
+							t.Errorf("error deleting pod: %v", err)
+						}
+					}()
+				}
+				return w, err
+			},
+		}
+		if err := WaitForDeletion(ctx, withDelete, &corev1.Pod{}); err != nil {
+			t.Fatalf("WaitForDeletion() error = %v", err)
+		}
+	})
+
+	t.Run("object already gone", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := WaitForDeletion(ctx, PodListWatch(ctx, client, "default", "pod"), &corev1.Pod{}); err != nil {
+			t.Fatalf("WaitForDeletion() error = %v", err)
+		}
+	})
+}
+
+func TestWaitForPodCount(t *testing.T) {
+	selector := labels.SelectorFromSet(labels.Set{"app": "web"})
+	objects := []runtime.Object{
+		testPod("web-1", map[string]string{"app": "web"}),
+		testPod("web-2", map[string]string{"app": "web"}),
+		testPod("db-1", map[string]string{"app": "db"}),
+	}
+
+	t.Run("enough pods", func(t *testing.T) {
+		client := fake.NewSimpleClientset(objects...)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		pods, err := WaitForPodCount(ctx, client, "default", selector, 2)
+		if err != nil {
+			t.Fatalf("WaitForPodCount() error = %v", err)
+		}
+		if len(pods) != 2 {
+			t.Errorf("WaitForPodCount() returned %d pods, want 2", len(pods))
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		client := fake.NewSimpleClientset(objects...)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		pods, err := WaitForPodCount(ctx, client, "default", selector, 3)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("WaitForPodCount() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+		if len(pods) != 2 {
+			t.Errorf("WaitForPodCount() returned %d pods, want 2", len(pods))
+		}
+	})
+}